@@ -1,17 +1,22 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"net/smtp"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	config "github.com/commitshark/notification-svc/internal"
 	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/dkim"
 	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/providers"
 	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/templates"
 )
@@ -43,6 +48,15 @@ func getEnvIntOrDefault(key string, fallback int) int {
 	return fallback
 }
 
+// newDKIMSigner returns a nil interface when DKIM isn't configured
+func newDKIMSigner(cfg config.DKIMConfig) (ports.MessageSigner, error) {
+	signer, err := dkim.NewSignerFromConfig(cfg)
+	if err != nil || signer == nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
 func requireAPIKey(next http.HandlerFunc, expected string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if expected == "" {
@@ -60,15 +74,50 @@ func requireAPIKey(next http.HandlerFunc, expected string) http.HandlerFunc {
 	}
 }
 
+// writeSendError answers with a status the worker's HTTP provider classifies
+// the same way: 422 for permanent failures, 429 with Retry-After when
+// throttled and 503 for anything worth retrying.
+func writeSendError(w http.ResponseWriter, err error) {
+	status := http.StatusServiceUnavailable
+
+	switch kind, retryAfter := domain.ClassifyFailure(err); kind {
+	case domain.FailurePermanent:
+		status = http.StatusUnprocessableEntity
+	case domain.FailureRateLimited:
+		if retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		}
+		status = http.StatusTooManyRequests
+	}
+
+	http.Error(w, "failed to send notification: "+err.Error(), status)
+}
+
 func main() {
 	cfg := config.LoadConfig()
 
 	// Renderer
-	renderer, err := templates.NewGoTemplateRenderer(templates.Files)
+	renderer, err := templates.NewGoTemplateRenderer(templates.Files, cfg.DefaultLocale)
 	if err != nil {
 		log.Fatalf("template init error: %v", err)
 	}
 
+	// Published templates are polled from the worker, which owns the template
+	// store; without it only the embedded templates are used
+	if cfg.TemplateSourceURL != "" {
+		source := templates.NewHTTPTemplateSource(cfg.TemplateSourceURL, cfg.HTTPEmail.APIKey)
+		go renderer.Watch(context.Background(), source, cfg.TemplateReloadInterval)
+	} else {
+		log.Println("TEMPLATE_SOURCE_URL not set, only embedded templates are used")
+	}
+
+	signer, err := newDKIMSigner(cfg.Email.DKIM)
+	if err != nil {
+		log.Fatalf("dkim init error: %v", err)
+	}
+
+	attachments := providers.NewAttachmentFetcher(cfg.Attachments.AllowedHosts)
+
 	auth := smtp.PlainAuth(
 		"",
 		cfg.Email.Username,
@@ -85,8 +134,15 @@ func main() {
 		cfg.Email.From,
 		renderer,
 		auth,
+		signer,
+		attachments,
 	)
 
+	marketingSigner, err := newDKIMSigner(cfg.MarketingEmail.DKIM)
+	if err != nil {
+		log.Fatalf("marketing dkim init error: %v", err)
+	}
+
 	marketingAuth := smtp.PlainAuth(
 		"",
 		cfg.MarketingEmail.Username,
@@ -105,6 +161,8 @@ func main() {
 		cfg.MarketingEmail.From,
 		renderer,
 		marketingAuth,
+		marketingSigner,
+		attachments,
 	)
 
 	// HTTP server
@@ -138,7 +196,7 @@ func main() {
 		providerResponse, err := provider.Send(&n)
 		if err != nil {
 			log.Println("Error sending email:", err.Error())
-			http.Error(w, "failed to send notification: "+err.Error(), http.StatusInternalServerError)
+			writeSendError(w, err)
 			return
 		}
 
@@ -147,6 +205,8 @@ func main() {
 			"status":   "sent",
 			"id":       n.ID,
 			"provider": providerResponse,
+
+			"template_version": n.TemplateVersion,
 		})
 
 		fmt.Println("Email sent →", providerResponse)
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	config "github.com/commitshark/notification-svc/internal"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/sqlite"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  up              apply all pending migrations
+  down <version>  revert the migrations above version (0 reverts all)
+  status          list migrations and when they were applied`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg := config.LoadConfig()
+
+	migrator, err := sqlite.NewMigrator(cfg.SQLite.Path)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer migrator.Close()
+
+	ctx := context.Background()
+
+	switch os.Args[1] {
+	case "up":
+		if err := migrator.Up(ctx); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		printStatus(ctx, migrator)
+
+	case "down":
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(2)
+		}
+		target, err := strconv.Atoi(os.Args[2])
+		if err != nil || target < 0 {
+			log.Fatalf("Invalid target version %q", os.Args[2])
+		}
+		if err := migrator.Down(ctx, target); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		printStatus(ctx, migrator)
+
+	case "status":
+		printStatus(ctx, migrator)
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func printStatus(ctx context.Context, migrator *sqlite.Migrator) {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		log.Fatalf("Failed to read migration status: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+	for _, s := range statuses {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.Local().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, applied)
+	}
+	w.Flush()
+}
@@ -4,19 +4,28 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	config "github.com/commitshark/notification-svc/internal"
 	"github.com/commitshark/notification-svc/internal/application/services"
+	"github.com/commitshark/notification-svc/internal/domain"
 	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/archive"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/cache"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/encryption"
 	grpcclient "github.com/commitshark/notification-svc/internal/infrastructure/adapters/grpc"
 	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/kafka"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/postgres"
 	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/providers"
 	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/sqlite"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/templates"
+	infragrpc "github.com/commitshark/notification-svc/internal/interfaces/grpc"
 	infrahttp "github.com/commitshark/notification-svc/internal/interfaces/http"
 
 	"google.golang.org/grpc"
@@ -32,8 +41,14 @@ func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
 
-	// Initialize SQLite repository
-	repo, err := sqlite.NewSQLiteNotificationRepository(cfg.SQLite.Path)
+	// Field-level encryption of stored notifications
+	keyring, err := loadKeyring(cfg.Encryption)
+	if err != nil {
+		log.Fatalf("Failed to load encryption keys: %v", err)
+	}
+
+	// Initialize notification repository
+	repo, err := newNotificationRepository(cfg, keyring)
 	if err != nil {
 		log.Fatalf("Failed to initialize repository: %v", err)
 	}
@@ -46,22 +61,81 @@ func main() {
 	}
 	defer conn.Close()
 
-	userDataAdapter := grpcclient.NewUserDataGRPCClient(conn)
+	userDataAdapter := grpcclient.NewUserDataGRPCClient(conn, cfg.UserGrpcTimeout)
+
+	// Cache contact info, invalidated by user change events when a topic is set
+	if cfg.UserCache.TTL > 0 {
+		userCache := cache.NewCachedUserDataAdapter(userDataAdapter, cfg.UserCache.TTL, cfg.UserCache.MaxEntries)
+		userDataAdapter = userCache
+
+		if cfg.Kafka.UserEventsTopic != "" {
+			userEvents := kafka.NewUserEventsConsumer(cfg.Kafka, userCache)
+			defer userEvents.Close()
+
+			go func() {
+				if err := userEvents.Start(ctx); err != nil {
+					log.Printf("User events consumer error: %v", err)
+				}
+			}()
+		} else {
+			log.Println("KAFKA_USER_EVENTS_TOPIC not set, cached contact info only expires by TTL")
+		}
+	}
+
+	// SMS and push render locally; email is rendered by the sender service
+	renderer, err := templates.NewGoTemplateRenderer(templates.Files, cfg.DefaultLocale)
+	if err != nil {
+		log.Fatalf("Failed to initialize template renderer: %v", err)
+	}
 
 	// Initialize providers
 	providerList := []ports.NotificationProvider{
 		providers.NewHTTPEmailProvider(cfg.HTTPEmail.Url, cfg.HTTPEmail.APIKey),
-		providers.NewSMSProvider(),
-		providers.NewPushProvider(),
+		providers.NewSMSProvider(renderer),
+		providers.NewPushProvider(renderer),
 	}
 
 	// Initialize service
-	notificationService := services.NewNotificationService(repo, providerList)
+	notificationService := services.NewNotificationService(repo, providerList, retryPolicies(cfg.Retry))
+
+	bulkService := services.NewBulkOperationService(repo, notificationService)
+	ingestionService := services.NewIngestionService(notificationService, repo, userDataAdapter, cfg.DefaultPhoneCountry)
+
+	campaignRepo, err := newCampaignRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize campaign repository: %v", err)
+	}
+	defer campaignRepo.Close()
+
+	campaignService := services.NewCampaignService(campaignRepo, repo, ingestionService)
+
+	notificationArchive, err := archive.NewJSONLArchive(cfg.Retention.ArchiveDir, keyring)
+	if err != nil {
+		log.Fatalf("Failed to initialize notification archive: %v", err)
+	}
+
+	policies, err := retentionPolicies(cfg.Retention.Policies)
+	if err != nil {
+		log.Fatalf("Invalid retention configuration: %v", err)
+	}
+
+	retentionService := services.NewRetentionService(repo, notificationArchive, policies, cfg.Retention.BatchSize)
 
 	// Kafka handler & consumer
-	kafkaHandler := kafka.NewKafkaMessageHandler(notificationService, userDataAdapter)
+	kafkaHandler := kafka.NewKafkaMessageHandler(ingestionService)
 	consumer := kafka.NewKafkaConsumer(cfg.Kafka, kafkaHandler)
 
+	// Start campaign dispatcher
+	go campaignService.Run(ctx)
+
+	// Start retention job
+	if len(policies) > 0 && cfg.Retention.Interval > 0 {
+		go retentionService.Run(ctx, cfg.Retention.Interval)
+	}
+
+	// Move rows left in plaintext or under a retired key to the current key
+	go reencryptNotifications(ctx, repo)
+
 	// Start retry worker
 	go startRetryWorker(ctx, notificationService, cfg.Service)
 
@@ -79,7 +153,23 @@ func main() {
 		}
 	}()
 
-	router := infrahttp.NewRouter(repo)
+	// Template management for the admin API
+	templateRepo, err := newTemplateRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize template repository: %v", err)
+	}
+	defer templateRepo.Close()
+
+	go renderer.Watch(ctx, templateRepo, cfg.TemplateReloadInterval)
+
+	templateService := services.NewTemplateService(templateRepo, renderer)
+
+	emailComposer, err := providers.NewEmailComposer(cfg.Email.From, renderer, providers.NewAttachmentFetcher(cfg.Attachments.AllowedHosts))
+	if err != nil {
+		log.Fatalf("Failed to initialize email composer: %v", err)
+	}
+
+	router := infrahttp.NewRouter(repo, notificationService, emailComposer, templateService, bulkService, campaignService, ingestionService, retentionService, cfg.Ingestion.APIKey, cfg.HTTPEmail.APIKey)
 
 	// HTTP server
 	server := &http.Server{
@@ -96,11 +186,32 @@ func main() {
 		serverErr <- server.ListenAndServe()
 	}()
 
+	// gRPC server
+	grpcServer := infragrpc.NewServer(
+		infragrpc.NewNotificationServer(repo, notificationService, ingestionService),
+		cfg.Ingestion.APIKey,
+	)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%v", cfg.GrpcPort))
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC: %v", err)
+	}
+
+	go func() {
+		log.Printf("🚀 gRPC server listening on :%v", cfg.GrpcPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Printf("gRPC server error: %v", err)
+		}
+	}()
+
 	sig := waitForShutdown()
 	log.Printf("Received signal: %v", sig)
 
 	cancel() // stop workers (kafka, retry, etc.)
 
+	log.Println("Shutting down gRPC server...")
+	stopGRPC(grpcServer, 10*time.Second)
+
 	log.Println("Shutting down HTTP server...")
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -137,8 +248,146 @@ func startRetryWorker(ctx context.Context, service *services.NotificationService
 	}
 }
 
+// loadKeyring loads the encryption keys, or returns nil when no keyfile is set
+func loadKeyring(cfg config.EncryptionConfig) (*encryption.Keyring, error) {
+	if cfg.KeyFile == "" {
+		log.Println("No encryption keyfile configured, notifications are stored unencrypted")
+		return nil, nil
+	}
+	return encryption.LoadKeyring(cfg.KeyFile)
+}
+
+// reencryptNotifications re-encrypts stored notifications in batches until
+// none are left under an old key
+func reencryptNotifications(ctx context.Context, repo ports.NotificationRepository) {
+	const batchSize = 200
+
+	total, skipped := 0, 0
+	afterID := ""
+	for ctx.Err() == nil {
+		batch, err := repo.Reencrypt(ctx, afterID, batchSize)
+		total += batch.Moved
+		if err != nil {
+			log.Printf("Re-encryption stopped after %d notifications: %v", total, err)
+			return
+		}
+
+		// Undecryptable rows are left for an operator instead of blocking the rest
+		for _, reason := range batch.Skipped {
+			log.Printf("Skipping re-encryption: %v", reason)
+		}
+		skipped += len(batch.Skipped)
+
+		if batch.LastID == "" {
+			break
+		}
+		afterID = batch.LastID
+	}
+
+	if total > 0 || skipped > 0 {
+		log.Printf("Re-encrypted %d notifications, skipped %d", total, skipped)
+	}
+}
+
+// newNotificationRepository opens the notification store of the configured backend
+func newNotificationRepository(cfg config.Config, keyring *encryption.Keyring) (ports.NotificationRepository, error) {
+	switch cfg.Storage.Backend {
+	case "", "sqlite":
+		return sqlite.NewSQLiteNotificationRepository(cfg.SQLite.Path, keyring)
+	case "postgres":
+		return postgres.NewPostgresNotificationRepository(cfg.Postgres.DSN, keyring)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}
+
+// newCampaignRepository opens the campaign store of the configured backend, so
+// replicas sharing Postgres dispatch the same campaigns
+func newCampaignRepository(cfg config.Config) (ports.CampaignRepository, error) {
+	switch cfg.Storage.Backend {
+	case "", "sqlite":
+		return sqlite.NewSQLiteCampaignRepository(cfg.SQLite.Path)
+	case "postgres":
+		return postgres.NewPostgresCampaignRepository(cfg.Postgres.DSN)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}
+
+// newTemplateRepository opens the template store of the configured backend, so
+// a template published on one replica reaches all of them
+func newTemplateRepository(cfg config.Config) (ports.TemplateRepository, error) {
+	switch cfg.Storage.Backend {
+	case "", "sqlite":
+		return sqlite.NewSQLiteTemplateRepository(cfg.SQLite.Path)
+	case "postgres":
+		return postgres.NewPostgresTemplateRepository(cfg.Postgres.DSN)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}
+
+// retryPolicies converts the retry configuration to the domain policies
+func retryPolicies(cfg config.RetryConfig) domain.RetryPolicies {
+	policy := func(c config.RetryPolicyConfig) domain.RetryPolicy {
+		return domain.RetryPolicy{
+			MaxAttempts: c.MaxAttempts,
+			BaseDelay:   c.BaseDelay,
+			MaxDelay:    c.MaxDelay,
+			Jitter:      c.Jitter,
+		}
+	}
+
+	policies := domain.RetryPolicies{
+		Default:   policy(cfg.RetryPolicyConfig),
+		Channels:  map[domain.NotificationType]domain.RetryPolicy{},
+		Templates: map[string]domain.RetryPolicy{},
+	}
+	for channel, c := range cfg.Channels {
+		policies.Channels[domain.NotificationType(strings.ToUpper(channel))] = policy(c)
+	}
+	for template, c := range cfg.Templates {
+		policies.Templates[strings.ToLower(template)] = policy(c)
+	}
+
+	return policies
+}
+
+// retentionPolicies converts and validates the retention configuration
+func retentionPolicies(cfgs []config.RetentionPolicyConfig) ([]domain.RetentionPolicy, error) {
+	policies := make([]domain.RetentionPolicy, 0, len(cfgs))
+	for _, c := range cfgs {
+		policy := domain.RetentionPolicy{
+			Status:   domain.NotificationStatus(strings.ToUpper(c.Status)),
+			Category: strings.ToLower(c.Category),
+			MaxAge:   c.MaxAge,
+		}
+		if err := policy.Validate(); err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
 func waitForShutdown() os.Signal {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	return <-sigChan
 }
+
+// stopGRPC drains in-flight calls, cutting off streams still open after timeout
+func stopGRPC(server *grpc.Server, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		server.Stop()
+	}
+}
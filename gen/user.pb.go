@@ -71,6 +71,7 @@ type GetUserContactInfoResponse struct {
 	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
 	Phone         *string                `protobuf:"bytes,3,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
 	Device        *string                `protobuf:"bytes,4,opt,name=device,proto3,oneof" json:"device,omitempty"`
+	Locale        *string                `protobuf:"bytes,5,opt,name=locale,proto3,oneof" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -133,6 +134,13 @@ func (x *GetUserContactInfoResponse) GetDevice() string {
 	return ""
 }
 
+func (x *GetUserContactInfoResponse) GetLocale() string {
+	if x != nil && x.Locale != nil {
+		return *x.Locale
+	}
+	return ""
+}
+
 type GetEventOrganizerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
@@ -420,15 +428,17 @@ const file_proto_user_proto_rawDesc = "" +
 	"\x10proto/user.proto\x12\x04user\"=\n" +
 	"\x19GetUserContactInfoRequest\x12 \n" +
 	"\fuser_auth_id\x18\x01 \x01(\tR\n" +
-	"userAuthId\"\xa4\x01\n" +
+	"userAuthId\"\xcc\x01\n" +
 	"\x1aGetUserContactInfoResponse\x12\x19\n" +
 	"\x05error\x18\x01 \x01(\tH\x00R\x05error\x88\x01\x01\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x19\n" +
 	"\x05phone\x18\x03 \x01(\tH\x01R\x05phone\x88\x01\x01\x12\x1b\n" +
-	"\x06device\x18\x04 \x01(\tH\x02R\x06device\x88\x01\x01B\b\n" +
+	"\x06device\x18\x04 \x01(\tH\x02R\x06device\x88\x01\x01\x12\x1b\n" +
+	"\x06locale\x18\x05 \x01(\tH\x03R\x06locale\x88\x01\x01B\b\n" +
 	"\x06_errorB\b\n" +
 	"\x06_phoneB\t\n" +
-	"\a_device\".\n" +
+	"\a_deviceB\t\n" +
+	"\a_locale\".\n" +
 	"\x18GetEventOrganizerRequest\x12\x12\n" +
 	"\x04slug\x18\x01 \x01(\tR\x04slug\"Y\n" +
 	"\x19GetEventOrganizerResponse\x12\x19\n" +
@@ -0,0 +1,60 @@
+package applicationdto
+
+import (
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
+
+// BulkFilterDto selects the notifications a bulk operation applies to
+type BulkFilterDto struct {
+	Status        string     `json:"status,omitempty"`
+	Type          string     `json:"type,omitempty"`
+	Template      string     `json:"template,omitempty"`
+	Recipient     string     `json:"recipient,omitempty"`
+	IsMarketing   *bool      `json:"is_marketing,omitempty"`
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+}
+
+type BulkOperationRequest struct {
+	Action        string        `json:"action"` // "requeue" or "resend"
+	Filter        BulkFilterDto `json:"filter"`
+	DryRun        bool          `json:"dry_run"`
+	RatePerSecond int           `json:"rate_per_second,omitempty"`
+}
+
+type BulkDryRunResponse struct {
+	Action  string `json:"action"`
+	Matched int    `json:"matched"`
+}
+
+// IsEmpty reports whether the filter would match every notification
+func (f BulkFilterDto) IsEmpty() bool {
+	return f.Status == "" && f.Type == "" && f.Template == "" && f.Recipient == "" &&
+		f.IsMarketing == nil && f.CreatedAfter == nil && f.CreatedBefore == nil
+}
+
+func (f BulkFilterDto) ToFilter() domain.NotificationFilter {
+	filter := domain.NotificationFilter{
+		IsMarketing:   f.IsMarketing,
+		Recipient:     f.Recipient,
+		CreatedAfter:  f.CreatedAfter,
+		CreatedBefore: f.CreatedBefore,
+	}
+
+	if f.Status != "" {
+		s := domain.NotificationStatus(f.Status)
+		filter.Status = &s
+	}
+	if f.Type != "" {
+		t := domain.NotificationType(f.Type)
+		filter.Type = &t
+	}
+	if f.Template != "" {
+		tpl := f.Template
+		filter.Template = &tpl
+	}
+
+	return filter
+}
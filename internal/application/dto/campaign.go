@@ -0,0 +1,79 @@
+package applicationdto
+
+import (
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
+
+type CreateCampaignRequest struct {
+	Name          string                 `json:"name"`
+	Channel       string                 `json:"channel,omitempty"` // defaults to EMAIL
+	Template      string                 `json:"template"`
+	Subject       string                 `json:"subject"`
+	Locale        string                 `json:"locale,omitempty"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+	UserIDs       []string               `json:"user_ids"`
+	RatePerSecond int                    `json:"rate_per_second,omitempty"`
+}
+
+type LaunchCampaignRequest struct {
+	// ScheduledAt delays the campaign; empty or past means start now
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+}
+
+// CampaignDto leaves out the audience, which can be very large
+type CampaignDto struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	Channel       string                 `json:"channel"`
+	Template      string                 `json:"template"`
+	Subject       string                 `json:"subject"`
+	Locale        string                 `json:"locale,omitempty"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+	Status        string                 `json:"status"`
+	RatePerSecond int                    `json:"rate_per_second"`
+	AudienceSize  int                    `json:"audience_size"`
+	Dispatched    int                    `json:"dispatched"`
+	Progress      float64                `json:"progress"` // 0 to 100
+	ScheduledAt   *time.Time             `json:"scheduled_at,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+	StartedAt     *time.Time             `json:"started_at,omitempty"`
+	CompletedAt   *time.Time             `json:"completed_at,omitempty"`
+}
+
+func ToCampaignDto(c *domain.Campaign) CampaignDto {
+	progress := 0.0
+	if c.Total() > 0 {
+		progress = float64(c.Cursor) * 100 / float64(c.Total())
+	}
+
+	return CampaignDto{
+		ID:            c.ID,
+		Name:          c.Name,
+		Channel:       string(c.Channel),
+		Template:      c.Template,
+		Subject:       c.Subject,
+		Locale:        c.Locale,
+		Data:          c.Data,
+		Status:        string(c.Status),
+		RatePerSecond: c.RatePerSecond,
+		AudienceSize:  c.Total(),
+		Dispatched:    c.Cursor,
+		Progress:      progress,
+		ScheduledAt:   c.ScheduledAt,
+		CreatedAt:     c.CreatedAt,
+		UpdatedAt:     c.UpdatedAt,
+		StartedAt:     c.StartedAt,
+		CompletedAt:   c.CompletedAt,
+	}
+}
+
+func ToCampaignDtos(campaigns []*domain.Campaign) []CampaignDto {
+	dtos := make([]CampaignDto, len(campaigns))
+	for i, c := range campaigns {
+		dtos[i] = ToCampaignDto(c)
+	}
+	return dtos
+}
@@ -0,0 +1,34 @@
+package applicationdto
+
+import (
+	"github.com/commitshark/notification-svc/internal/domain/events"
+)
+
+// MaxBatchSize caps the notifications accepted in one batch request
+const MaxBatchSize = 100
+
+type CreateNotificationsRequest struct {
+	Notifications []events.NotificationMessagePayload `json:"notifications"`
+}
+
+type CreateNotificationResponse struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	Duplicate bool   `json:"duplicate,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// BatchItemResult is the outcome of one notification in a batch; exactly one
+// of ID and Error is set
+type BatchItemResult struct {
+	Index     int    `json:"index"`
+	ID        string `json:"id,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Duplicate bool   `json:"duplicate,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type CreateNotificationsResponse struct {
+	Results []BatchItemResult `json:"results"`
+}
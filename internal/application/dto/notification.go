@@ -1,6 +1,9 @@
 package applicationdto
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/commitshark/notification-svc/internal/domain"
@@ -14,6 +17,11 @@ type ListNotificationsRequest struct {
 	Type        string `json:"-"`
 	Query       string `json:"-"`
 	IsMarketing *bool  `json:"-"`
+
+	Template      string     `json:"-"`
+	Recipient     string     `json:"-"`
+	CreatedAfter  *time.Time `json:"-"`
+	CreatedBefore *time.Time `json:"-"`
 }
 
 type NotificationDto struct {
@@ -29,6 +37,7 @@ type NotificationDto struct {
 	MaxRetries       int                       `json:"max_retries"`
 	Version          int                       `json:"version"`
 	IsMarketing      int                       `json:"is_marketing"`
+	TemplateVersion  int                       `json:"template_version"`
 }
 
 func ToNotificationDtos(notifications []*domain.Notification) []*NotificationDto {
@@ -48,8 +57,125 @@ func ToNotificationDtos(notifications []*domain.Notification) []*NotificationDto
 			MaxRetries:       n.MaxRetries,
 			Version:          n.Version,
 			IsMarketing:      n.IsMarketing,
+			TemplateVersion:  n.TemplateVersion,
 		})
 	}
 
 	return dtos
 }
+
+const maskedValue = "••••••"
+
+// sensitiveKey matches data keys whose values are secrets, e.g. "otp_code"
+var sensitiveKey = regexp.MustCompile(`(?i)(^|_)(otp|password|passcode|secret|token|pin|cvv|code|qr)(_|$)`)
+
+type AttachmentDto struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+	Disposition string `json:"disposition,omitempty"`
+	Size        int    `json:"size,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+type ContentDto struct {
+	Title       string                 `json:"title"`
+	Body        *string                `json:"body,omitempty"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+	HTML        *string                `json:"html,omitempty"`
+	Template    *string                `json:"template,omitempty"`
+	Locale      string                 `json:"locale,omitempty"`
+	Attachments []AttachmentDto        `json:"attachments,omitempty"`
+}
+
+// NotificationDetailDto is the full notification with secrets masked
+type NotificationDetailDto struct {
+	NotificationDto
+	Content    ContentDto `json:"content"`
+	ResentFrom *string    `json:"resent_from,omitempty"`
+}
+
+func ToNotificationDetailDto(n *domain.Notification) *NotificationDetailDto {
+	var data map[string]interface{}
+	var secrets []string
+	if n.Content.Data != nil {
+		data, secrets = maskData(*n.Content.Data)
+	}
+
+	attachments := make([]AttachmentDto, 0, len(n.Content.Attachments))
+	for _, a := range n.Content.Attachments {
+		attachments = append(attachments, AttachmentDto{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Disposition: string(a.Disposition),
+			Size:        len(a.Data),
+			URL:         a.URL,
+		})
+	}
+
+	title := *maskSecrets(&n.Content.Title, secrets)
+	summary := ToNotificationDtos([]*domain.Notification{n})[0]
+	summary.ContentTitle = title
+
+	return &NotificationDetailDto{
+		NotificationDto: *summary,
+		Content: ContentDto{
+			Title:       title,
+			Body:        maskSecrets(n.Content.Body, secrets),
+			Data:        data,
+			HTML:        maskSecrets(n.Content.HTML, secrets),
+			Template:    n.Content.Template,
+			Locale:      n.Content.Locale,
+			Attachments: attachments,
+		},
+		ResentFrom: n.ResentFrom,
+	}
+}
+
+// maskData copies data with sensitive values masked and returns those values so
+// they can be masked in the rendered body too
+func maskData(data map[string]interface{}) (map[string]interface{}, []string) {
+	var secrets []string
+
+	var walk func(v interface{}, sensitive bool) interface{}
+	walk = func(v interface{}, sensitive bool) interface{} {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			out := make(map[string]interface{}, len(val))
+			for k, child := range val {
+				out[k] = walk(child, sensitive || sensitiveKey.MatchString(k))
+			}
+			return out
+		case []interface{}:
+			out := make([]interface{}, len(val))
+			for i, child := range val {
+				out[i] = walk(child, sensitive)
+			}
+			return out
+		default:
+			if !sensitive || v == nil {
+				return v
+			}
+			if s := fmt.Sprint(v); s != "" {
+				secrets = append(secrets, s)
+			}
+			return maskedValue
+		}
+	}
+
+	return walk(data, false).(map[string]interface{}), secrets
+}
+
+func maskSecrets(s *string, secrets []string) *string {
+	if s == nil {
+		return nil
+	}
+
+	masked := *s
+	for _, secret := range secrets {
+		// Very short values would mask unrelated text
+		if len(secret) >= 4 {
+			masked = strings.ReplaceAll(masked, secret, maskedValue)
+		}
+	}
+	return &masked
+}
@@ -0,0 +1,111 @@
+package applicationdto
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
+
+func TestMaskData(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		want    map[string]interface{}
+		secrets []string
+	}{
+		{
+			name:    "sensitive keys",
+			data:    map[string]interface{}{"otp_code": "482913", "Password": "hunter22", "qr": "QR-1", "name": "Ada"},
+			want:    map[string]interface{}{"otp_code": maskedValue, "Password": maskedValue, "qr": maskedValue, "name": "Ada"},
+			secrets: []string{"482913", "QR-1", "hunter22"},
+		},
+		{
+			name:    "keys that only contain a word",
+			data:    map[string]interface{}{"postcode": "SW1A", "pinned": true, "tokens_left": 3, "event_code": 9321},
+			want:    map[string]interface{}{"postcode": "SW1A", "pinned": true, "tokens_left": 3, "event_code": maskedValue},
+			secrets: []string{"9321"},
+		},
+		{
+			name: "nested",
+			data: map[string]interface{}{
+				"ticket": map[string]interface{}{"id": "t1", "token": "abcd1234"},
+				"secret": map[string]interface{}{"a": "x1y2", "b": []interface{}{"z3z3", nil}},
+				"items":  []interface{}{map[string]interface{}{"pin": "1234"}},
+			},
+			want: map[string]interface{}{
+				"ticket": map[string]interface{}{"id": "t1", "token": maskedValue},
+				"secret": map[string]interface{}{"a": maskedValue, "b": []interface{}{maskedValue, nil}},
+				"items":  []interface{}{map[string]interface{}{"pin": maskedValue}},
+			},
+			secrets: []string{"1234", "abcd1234", "x1y2", "z3z3"},
+		},
+		{
+			name:    "empty values",
+			data:    map[string]interface{}{"otp": "", "token": nil},
+			want:    map[string]interface{}{"otp": maskedValue, "token": nil},
+			secrets: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, secrets := maskData(tt.data)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("masked = %v, want %v", got, tt.want)
+			}
+			if !sameStrings(secrets, tt.secrets) {
+				t.Errorf("secrets = %v, want %v", secrets, tt.secrets)
+			}
+		})
+	}
+}
+
+func TestToNotificationDetailDtoMasksRenderedContent(t *testing.T) {
+	email := "a@example.com"
+	body := "Your code is 482913. Reply 12 to stop."
+	html := "<p>Code: <b>482913</b></p>"
+	data := map[string]interface{}{"otp_code": "482913", "short_pin": "12"}
+
+	n, err := domain.NewNotification("n1", domain.EmailNotification, domain.Recipient{ID: "u1", Email: &email},
+		domain.Content{Title: "482913 is your code", Body: &body, HTML: &html, Data: &data}, 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dto := ToNotificationDetailDto(n)
+
+	if strings.Contains(dto.Content.Title, "482913") || dto.ContentTitle != dto.Content.Title {
+		t.Errorf("title = %q, summary title = %q", dto.Content.Title, dto.ContentTitle)
+	}
+	if strings.Contains(*dto.Content.Body, "482913") || strings.Contains(*dto.Content.HTML, "482913") {
+		t.Errorf("body = %q, html = %q", *dto.Content.Body, *dto.Content.HTML)
+	}
+	// Values too short to mask safely are left in the text, but not in data
+	if !strings.Contains(*dto.Content.Body, "Reply 12") || dto.Content.Data["short_pin"] != maskedValue {
+		t.Errorf("body = %q, data = %v", *dto.Content.Body, dto.Content.Data)
+	}
+	if (*n.Content.Data)["otp_code"] != "482913" || *n.Content.Body != body {
+		t.Error("masking changed the notification")
+	}
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+	}
+	for _, n := range counts {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+package applicationdto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	domain_template "github.com/commitshark/notification-svc/internal/domain/templates"
+)
+
+type TemplateDto struct {
+	Name      string                 `json:"name"`
+	PreHeader string                 `json:"pre_header,omitempty"`
+	Headers   map[string]string      `json:"headers,omitempty"`
+	Sample    map[string]interface{} `json:"sample,omitempty"`
+	// PublishedVersion is the live stored version, 0 when the embedded copy is used
+	PublishedVersion int `json:"published_version"`
+	// Overrides lists published locale/layout variants, e.g. "welcome.fr"
+	Overrides map[string]int `json:"overrides,omitempty"`
+}
+
+type TemplateVersionDto struct {
+	Name        string                `json:"name"`
+	Version     int                   `json:"version"`
+	Status      domain.TemplateStatus `json:"status"`
+	Body        string                `json:"body,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+	PublishedAt *time.Time            `json:"published_at,omitempty"`
+}
+
+type SaveTemplateVersionRequest struct {
+	Body string `json:"body"`
+}
+
+// PreviewTemplateRequest renders a template; Data defaults to the built-in sample
+type PreviewTemplateRequest struct {
+	Data    *map[string]interface{} `json:"data,omitempty"`
+	Subject string                  `json:"subject,omitempty"`
+	Locale  string                  `json:"locale,omitempty"`
+}
+
+type PreviewTemplateResponse struct {
+	Template string `json:"template"`
+	Subject  string `json:"subject"`
+	HTML     string `json:"html"`
+	Text     string `json:"text"`
+	MIME     string `json:"mime"`
+}
+
+// SendTestTemplateRequest sends a rendered template straight to To
+type SendTestTemplateRequest struct {
+	To      string                  `json:"to"`
+	Data    *map[string]interface{} `json:"data,omitempty"`
+	Subject string                  `json:"subject,omitempty"`
+	Locale  string                  `json:"locale,omitempty"`
+}
+
+type SendTestTemplateResponse struct {
+	ID     string `json:"id"`
+	To     string `json:"to"`
+	Status string `json:"status"`
+}
+
+func ToTemplateDtos(defs []domain_template.Definition, published map[string]int) []*TemplateDto {
+	dtos := make([]*TemplateDto, 0, len(defs))
+
+	for _, d := range defs {
+		sample, _ := domain_template.SampleData(d.Name)
+		dtos = append(dtos, &TemplateDto{
+			Name:             d.Name,
+			PreHeader:        d.PreHeader,
+			Headers:          d.Headers,
+			Sample:           sample,
+			PublishedVersion: published[d.Name],
+			Overrides:        variantsOf(d.Name, published),
+		})
+	}
+
+	return dtos
+}
+
+func variantsOf(name string, published map[string]int) map[string]int {
+	var variants map[string]int
+	for key, version := range published {
+		if strings.HasPrefix(key, name+".") {
+			if variants == nil {
+				variants = map[string]int{}
+			}
+			variants[key] = version
+		}
+	}
+	return variants
+}
+
+// ToTemplateVersionDto includes the body; list views leave it out
+func ToTemplateVersionDto(t *domain.TemplateVersion, withBody bool) *TemplateVersionDto {
+	dto := &TemplateVersionDto{
+		Name:        t.Name,
+		Version:     t.Version,
+		Status:      t.Status,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		PublishedAt: t.PublishedAt,
+	}
+	if withBody {
+		dto.Body = t.Body
+	}
+	return dto
+}
+
+func ToTemplateVersionDtos(templates []*domain.TemplateVersion) []*TemplateVersionDto {
+	dtos := make([]*TemplateVersionDto, 0, len(templates))
+	for _, t := range templates {
+		dtos = append(dtos, ToTemplateVersionDto(t, false))
+	}
+	return dtos
+}
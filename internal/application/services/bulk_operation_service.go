@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/google/uuid"
+)
+
+type BulkAction string
+
+const (
+	// BulkRequeue resets the retries of the matches and sends them again
+	BulkRequeue BulkAction = "requeue"
+	// BulkResend sends linked copies of the matches
+	BulkResend BulkAction = "resend"
+)
+
+type BulkJobStatus string
+
+const (
+	BulkJobRunning   BulkJobStatus = "RUNNING"
+	BulkJobCompleted BulkJobStatus = "COMPLETED"
+	BulkJobCancelled BulkJobStatus = "CANCELLED"
+)
+
+const (
+	DefaultBulkRate = 10
+	MaxBulkRate     = 50
+	// keep the last errors only, a job can touch thousands of notifications
+	maxBulkJobErrors = 20
+	// maxFinishedBulkJobs bounds the finished jobs kept for inspection
+	maxFinishedBulkJobs = 50
+)
+
+var (
+	ErrUnknownBulkAction = errors.New("unknown bulk action")
+	ErrBulkJobNotFound   = errors.New("bulk job not found")
+	ErrBulkJobFinished   = errors.New("bulk job already finished")
+)
+
+// BulkJob is a snapshot of a running or finished bulk operation
+type BulkJob struct {
+	ID         string                    `json:"id"`
+	Action     BulkAction                `json:"action"`
+	Filter     domain.NotificationFilter `json:"filter"`
+	Status     BulkJobStatus             `json:"status"`
+	RatePerSec int                       `json:"rate_per_second"`
+	Total      int                       `json:"total"`
+	Processed  int                       `json:"processed"`
+	Succeeded  int                       `json:"succeeded"`
+	Failed     int                       `json:"failed"`
+	Errors     []string                  `json:"errors,omitempty"`
+	StartedAt  time.Time                 `json:"started_at"`
+	FinishedAt *time.Time                `json:"finished_at,omitempty"`
+}
+
+type bulkJob struct {
+	mu     sync.Mutex
+	job    BulkJob
+	cancel context.CancelFunc
+}
+
+// BulkOperationService runs requeue/resend jobs over filtered notifications in
+// the background. Jobs are tracked in memory only: a restart stops the running
+// ones and forgets them all, and only the latest finished jobs are kept.
+type BulkOperationService struct {
+	repo          ports.NotificationRepository
+	notifications *NotificationService
+
+	mu   sync.RWMutex
+	jobs map[string]*bulkJob
+}
+
+func NewBulkOperationService(repo ports.NotificationRepository, notifications *NotificationService) *BulkOperationService {
+	return &BulkOperationService{
+		repo:          repo,
+		notifications: notifications,
+		jobs:          make(map[string]*bulkJob),
+	}
+}
+
+// Count returns how many notifications a job of action with filter would touch
+func (s *BulkOperationService) Count(ctx context.Context, action BulkAction, filter domain.NotificationFilter) (int, error) {
+	if action != BulkRequeue && action != BulkResend {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownBulkAction, action)
+	}
+
+	total := 0
+	for _, f := range scope(action, filter) {
+		counts, err := s.repo.CountByStatus(ctx, f)
+		if err != nil {
+			return 0, err
+		}
+		for _, n := range counts {
+			total += n
+		}
+	}
+	return total, nil
+}
+
+// scope narrows filter to the notifications action applies to: requeue only
+// takes rejected notifications and failed ones out of retries
+func scope(action BulkAction, filter domain.NotificationFilter) []domain.NotificationFilter {
+	if action != BulkRequeue {
+		return []domain.NotificationFilter{filter}
+	}
+
+	var scoped []domain.NotificationFilter
+	for _, status := range domain.RequeueableStatuses {
+		if filter.Status != nil && *filter.Status != "" && *filter.Status != status {
+			continue
+		}
+
+		f := filter
+		f.Status = &status
+		f.Settled = true
+		scoped = append(scoped, f)
+	}
+	return scoped
+}
+
+// Start snapshots the matching notifications and processes them at most
+// ratePerSec per second until done or cancelled.
+func (s *BulkOperationService) Start(ctx context.Context, action BulkAction, filter domain.NotificationFilter, ratePerSec int) (*BulkJob, error) {
+	if action != BulkRequeue && action != BulkResend {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBulkAction, action)
+	}
+
+	if ratePerSec <= 0 {
+		ratePerSec = DefaultBulkRate
+	}
+	if ratePerSec > MaxBulkRate {
+		ratePerSec = MaxBulkRate
+	}
+
+	var ids []string
+	for _, f := range scope(action, filter) {
+		matched, err := s.repo.FindIDs(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, matched...)
+	}
+
+	// The job outlives the request that started it
+	jobCtx, cancel := context.WithCancel(context.Background())
+
+	j := &bulkJob{
+		job: BulkJob{
+			ID:         uuid.NewString(),
+			Action:     action,
+			Filter:     filter,
+			Status:     BulkJobRunning,
+			RatePerSec: ratePerSec,
+			Total:      len(ids),
+			StartedAt:  time.Now(),
+		},
+		cancel: cancel,
+	}
+
+	s.mu.Lock()
+	s.pruneLocked()
+	s.jobs[j.job.ID] = j
+	s.mu.Unlock()
+
+	go s.run(jobCtx, j, ids)
+
+	snapshot := j.snapshot()
+	return &snapshot, nil
+}
+
+// pruneLocked forgets the oldest finished jobs beyond maxFinishedBulkJobs
+func (s *BulkOperationService) pruneLocked() {
+	var finished []BulkJob
+	for _, j := range s.jobs {
+		if snapshot := j.snapshot(); snapshot.FinishedAt != nil {
+			finished = append(finished, snapshot)
+		}
+	}
+	if len(finished) < maxFinishedBulkJobs {
+		return
+	}
+
+	sort.Slice(finished, func(a, b int) bool { return finished[a].FinishedAt.Before(*finished[b].FinishedAt) })
+	for _, job := range finished[:len(finished)-maxFinishedBulkJobs+1] {
+		delete(s.jobs, job.ID)
+	}
+}
+
+func (s *BulkOperationService) Get(id string) (*BulkJob, error) {
+	s.mu.RLock()
+	j, ok := s.jobs[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrBulkJobNotFound
+	}
+
+	snapshot := j.snapshot()
+	return &snapshot, nil
+}
+
+// List returns all jobs, newest first
+func (s *BulkOperationService) List() []BulkJob {
+	s.mu.RLock()
+	jobs := make([]BulkJob, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j.snapshot())
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(jobs, func(a, b int) bool { return jobs[a].StartedAt.After(jobs[b].StartedAt) })
+	return jobs
+}
+
+func (s *BulkOperationService) Cancel(id string) (*BulkJob, error) {
+	s.mu.RLock()
+	j, ok := s.jobs[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrBulkJobNotFound
+	}
+
+	j.mu.Lock()
+	running := j.job.Status == BulkJobRunning
+	j.mu.Unlock()
+	if !running {
+		return nil, ErrBulkJobFinished
+	}
+
+	j.cancel()
+
+	snapshot := j.snapshot()
+	return &snapshot, nil
+}
+
+func (s *BulkOperationService) run(ctx context.Context, j *bulkJob, ids []string) {
+	defer j.cancel()
+
+	ticker := time.NewTicker(time.Second / time.Duration(j.job.RatePerSec))
+	defer ticker.Stop()
+
+	for _, id := range ids {
+		select {
+		case <-ctx.Done():
+			j.finish(BulkJobCancelled)
+			log.Printf("[BulkOperation] job %s cancelled", j.job.ID)
+			return
+		case <-ticker.C:
+		}
+
+		err := s.apply(ctx, j.job.Action, id)
+		j.record(id, err)
+	}
+
+	j.finish(BulkJobCompleted)
+	log.Printf("[BulkOperation] job %s completed", j.job.ID)
+}
+
+func (s *BulkOperationService) apply(ctx context.Context, action BulkAction, id string) error {
+	switch action {
+	case BulkRequeue:
+		return s.notifications.RequeueNotification(ctx, id)
+	case BulkResend:
+		_, err := s.notifications.ResendNotification(ctx, id, uuid.NewString())
+		return err
+	default:
+		return ErrUnknownBulkAction
+	}
+}
+
+func (j *bulkJob) record(id string, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.job.Processed++
+	if err == nil {
+		j.job.Succeeded++
+		return
+	}
+
+	j.job.Failed++
+	j.job.Errors = append(j.job.Errors, fmt.Sprintf("%s: %v", id, err))
+	if len(j.job.Errors) > maxBulkJobErrors {
+		j.job.Errors = j.job.Errors[len(j.job.Errors)-maxBulkJobErrors:]
+	}
+}
+
+func (j *bulkJob) finish(status BulkJobStatus) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	j.job.Status = status
+	j.job.FinishedAt = &now
+}
+
+func (j *bulkJob) snapshot() BulkJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	snapshot := j.job
+	snapshot.Errors = append([]string(nil), j.job.Errors...)
+	return snapshot
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+)
+
+// countingRepo answers CountByStatus from counts, honouring the status and
+// settled parts of the filter
+type countingRepo struct {
+	ports.NotificationRepository
+	counts map[domain.NotificationStatus]int
+	// retrying are the failed notifications with retries left
+	retrying int
+}
+
+func (r *countingRepo) CountByStatus(ctx context.Context, filter domain.NotificationFilter) (map[domain.NotificationStatus]int, error) {
+	counts := map[domain.NotificationStatus]int{}
+	for status, n := range r.counts {
+		if filter.Status != nil && *filter.Status != status {
+			continue
+		}
+		if status == domain.StatusFailed && filter.Settled {
+			n -= r.retrying
+		}
+		counts[status] = n
+	}
+	return counts, nil
+}
+
+func TestBulkOperationCount(t *testing.T) {
+	repo := &countingRepo{
+		counts: map[domain.NotificationStatus]int{
+			domain.StatusPending:   4,
+			domain.StatusFailed:    5,
+			domain.StatusRejected:  2,
+			domain.StatusSent:      7,
+			domain.StatusCancelled: 1,
+		},
+		retrying: 3,
+	}
+	service := NewBulkOperationService(repo, nil)
+
+	failed := domain.StatusFailed
+	sent := domain.StatusSent
+
+	tests := []struct {
+		name   string
+		action BulkAction
+		filter domain.NotificationFilter
+		want   int
+	}{
+		{"requeue takes rejected and exhausted failures", BulkRequeue, domain.NotificationFilter{}, 4},
+		{"requeue of failed", BulkRequeue, domain.NotificationFilter{Status: &failed}, 2},
+		{"requeue of sent", BulkRequeue, domain.NotificationFilter{Status: &sent}, 0},
+		{"resend takes everything", BulkResend, domain.NotificationFilter{}, 19},
+		{"resend of sent", BulkResend, domain.NotificationFilter{Status: &sent}, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.Count(context.Background(), tt.action, tt.filter)
+			if err != nil {
+				t.Fatalf("Count: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Count = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkOperationPrunesFinishedJobs(t *testing.T) {
+	service := NewBulkOperationService(nil, nil)
+
+	base := time.Now()
+	for i := 0; i < maxFinishedBulkJobs+5; i++ {
+		finished := base.Add(time.Duration(i) * time.Second)
+		service.jobs[string(rune('a'+i))] = &bulkJob{job: BulkJob{
+			ID:         string(rune('a' + i)),
+			Status:     BulkJobCompleted,
+			FinishedAt: &finished,
+		}}
+	}
+	service.jobs["running"] = &bulkJob{job: BulkJob{ID: "running", Status: BulkJobRunning}}
+
+	service.pruneLocked()
+
+	if _, ok := service.jobs["running"]; !ok {
+		t.Error("running job was pruned")
+	}
+	if _, ok := service.jobs["a"]; ok {
+		t.Error("oldest finished job was kept")
+	}
+	if len(service.jobs) != maxFinishedBulkJobs {
+		t.Errorf("%d jobs left, want %d with room for the new one", len(service.jobs), maxFinishedBulkJobs)
+	}
+}
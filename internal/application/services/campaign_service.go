@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/events"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+)
+
+// campaignTick is how often the dispatcher sends the next batch of each campaign
+const campaignTick = time.Second
+
+// CampaignStats reports a campaign's progress and how its notifications fared
+type CampaignStats struct {
+	Total      int                               `json:"total"`
+	Dispatched int                               `json:"dispatched"`
+	Remaining  int                               `json:"remaining"`
+	Delivery   map[domain.NotificationStatus]int `json:"delivery"`
+}
+
+// CampaignService manages campaigns and dispatches them. Each campaign's
+// audience is expanded into notifications at its own rate through the regular
+// ingestion path, so recipients get the same lookup and validation.
+type CampaignService struct {
+	campaigns        ports.CampaignRepository
+	notificationRepo ports.NotificationRepository
+	ingestion        *IngestionService
+}
+
+func NewCampaignService(
+	campaigns ports.CampaignRepository,
+	notificationRepo ports.NotificationRepository,
+	ingestion *IngestionService,
+) *CampaignService {
+	return &CampaignService{
+		campaigns:        campaigns,
+		notificationRepo: notificationRepo,
+		ingestion:        ingestion,
+	}
+}
+
+// Create stores a new draft campaign after checking its template data
+func (s *CampaignService) Create(ctx context.Context, c *domain.Campaign) error {
+	if err := s.ingestion.Validate(campaignPayload(c, c.Audience.UserIDs[0])); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrCampaignInvalid, err)
+	}
+
+	if err := s.campaigns.Save(ctx, c); err != nil {
+		return fmt.Errorf("failed to create campaign: %w", err)
+	}
+	return nil
+}
+
+func (s *CampaignService) Get(ctx context.Context, id string) (*domain.Campaign, error) {
+	return s.campaigns.FindByID(ctx, id)
+}
+
+func (s *CampaignService) List(ctx context.Context) ([]*domain.Campaign, error) {
+	return s.campaigns.List(ctx)
+}
+
+// Launch starts a draft campaign, or schedules it when at is in the future
+func (s *CampaignService) Launch(ctx context.Context, id string, at *time.Time) (*domain.Campaign, error) {
+	return s.update(ctx, id, func(c *domain.Campaign, now time.Time) error {
+		return c.Launch(at, now)
+	})
+}
+
+func (s *CampaignService) Pause(ctx context.Context, id string) (*domain.Campaign, error) {
+	return s.update(ctx, id, (*domain.Campaign).Pause)
+}
+
+func (s *CampaignService) Resume(ctx context.Context, id string) (*domain.Campaign, error) {
+	return s.update(ctx, id, (*domain.Campaign).Resume)
+}
+
+// Cancel stops a campaign for good; notifications already created still go out
+func (s *CampaignService) Cancel(ctx context.Context, id string) (*domain.Campaign, error) {
+	return s.update(ctx, id, (*domain.Campaign).Cancel)
+}
+
+func (s *CampaignService) Stats(ctx context.Context, id string) (*CampaignStats, error) {
+	c, err := s.campaigns.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.notificationRepo.CountByStatus(ctx, domain.NotificationFilter{CampaignID: &c.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CampaignStats{
+		Total:      c.Total(),
+		Dispatched: c.Cursor,
+		Remaining:  c.Total() - c.Cursor,
+		Delivery:   delivery,
+	}, nil
+}
+
+func (s *CampaignService) update(ctx context.Context, id string, change func(*domain.Campaign, time.Time) error) (*domain.Campaign, error) {
+	c, err := s.campaigns.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := change(c, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.campaigns.Save(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Run dispatches due campaigns until ctx is cancelled
+func (s *CampaignService) Run(ctx context.Context) {
+	ticker := time.NewTicker(campaignTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.dispatchDue(ctx)
+		}
+	}
+}
+
+func (s *CampaignService) dispatchDue(ctx context.Context) {
+	campaigns, err := s.campaigns.FindActive(ctx)
+	if err != nil {
+		log.Printf("[Campaign] failed to load active campaigns: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, c := range campaigns {
+		if !c.IsDue(now) {
+			continue
+		}
+		if err := s.dispatch(ctx, c, now); err != nil {
+			log.Printf("[Campaign] %s: %v", c.ID, err)
+		}
+	}
+}
+
+// dispatch sends one batch of c. Notification IDs are derived from the
+// campaign and audience position, so a batch that is sent again after a crash
+// or a lost progress update doesn't notify anyone twice.
+func (s *CampaignService) dispatch(ctx context.Context, c *domain.Campaign, now time.Time) error {
+	if c.Status == domain.CampaignScheduled {
+		c.Start(now)
+		if err := s.campaigns.Save(ctx, c); err != nil {
+			return err
+		}
+		log.Printf("[Campaign] %s started", c.ID)
+	}
+
+	limit := int(float64(c.RatePerSecond) * campaignTick.Seconds())
+	if limit < 1 {
+		limit = 1
+	}
+
+	sent := 0
+	for i, userID := range c.NextBatch(limit) {
+		id := IdempotentID("campaign", c.ID, c.Cursor+i)
+
+		_, err := s.ingestion.ingest(ctx, id, campaignPayload(c, userID), &c.ID)
+		if err != nil && !errors.Is(err, ErrInvalidPayload) {
+			// Transient, e.g. the user service is down: retry from here next tick
+			log.Printf("[Campaign] %s: stopping batch at %s: %v", c.ID, userID, err)
+			break
+		}
+		if err != nil {
+			log.Printf("[Campaign] %s: skipping %s: %v", c.ID, userID, err)
+		}
+		sent++
+	}
+
+	if sent == 0 && c.Cursor < c.Total() {
+		return nil
+	}
+
+	return s.advance(ctx, c.ID, c.Cursor, sent)
+}
+
+// advance records progress on a fresh copy, so a pause or cancel made while
+// the batch was sending isn't overwritten
+func (s *CampaignService) advance(ctx context.Context, id string, from, sent int) error {
+	for attempt := 0; attempt < 3; attempt++ {
+		c, err := s.campaigns.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if c.Cursor != from {
+			return nil // someone else already recorded this batch
+		}
+
+		c.Advance(sent, time.Now())
+
+		err = s.campaigns.Save(ctx, c)
+		if errors.Is(err, domain.ErrCampaignConflict) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if c.Status == domain.CampaignCompleted {
+			log.Printf("[Campaign] %s completed, %d notifications", c.ID, c.Total())
+		}
+		return nil
+	}
+
+	return fmt.Errorf("failed to record progress: %w", domain.ErrCampaignConflict)
+}
+
+// campaignPayload is the notification request sent to one audience member
+func campaignPayload(c *domain.Campaign, userID string) events.NotificationMessagePayload {
+	data := make(map[string]any, len(c.Data))
+	for k, v := range c.Data {
+		data[k] = v
+	}
+
+	template := c.Template
+	payload := events.NotificationMessagePayload{
+		Type:     "shell",
+		Channel:  string(c.Channel),
+		UserID:   userID,
+		Subject:  c.Subject,
+		Template: &template,
+		Data:     &data,
+	}
+
+	if c.Locale != "" {
+		locale := c.Locale
+		payload.Locale = &locale
+	}
+
+	return payload
+}
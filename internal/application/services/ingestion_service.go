@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/events"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	domain_template "github.com/commitshark/notification-svc/internal/domain/templates"
+	"github.com/google/uuid"
+)
+
+// idempotencyNamespace scopes the notification IDs derived from idempotency keys
+var idempotencyNamespace = uuid.MustParse("6f1c7f0e-3b8e-4c1a-9d55-0b7f4a2e9c31")
+
+var (
+	// ErrInvalidPayload marks a request that can never become a notification
+	ErrInvalidPayload = errors.New("invalid notification payload")
+	// ErrIdempotencyKeyReused means a replayed idempotency key came with another payload
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different payload")
+)
+
+// IngestResult describes the notification created for a request
+type IngestResult struct {
+	ID     string                    `json:"id"`
+	Status domain.NotificationStatus `json:"status"`
+	// Duplicate is set when the ID already existed and nothing was created
+	Duplicate bool   `json:"duplicate,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// IngestionService turns notification requests from any transport (Kafka,
+// HTTP) into notifications: validation, contact lookup and normalization.
+type IngestionService struct {
+	notifications  *NotificationService
+	repo           ports.NotificationRepository
+	userDataSource ports.UserDataAdapter
+	// phoneCountry is the country of phone numbers stored without a prefix
+	phoneCountry string
+}
+
+func NewIngestionService(
+	notifications *NotificationService,
+	repo ports.NotificationRepository,
+	userAdapter ports.UserDataAdapter,
+	phoneCountry string,
+) *IngestionService {
+	return &IngestionService{
+		notifications:  notifications,
+		repo:           repo,
+		userDataSource: userAdapter,
+		phoneCountry:   phoneCountry,
+	}
+}
+
+// IdempotentID derives a stable notification ID from a caller's idempotency key
+// and the request's index in a batch, so a replayed request maps to the
+// notification it created the first time. Keys are scoped to caller, which
+// must not contain "/", so two callers can't collide on one. An empty key gets
+// a random ID.
+func IdempotentID(caller, key string, index int) string {
+	if key == "" {
+		return uuid.NewString()
+	}
+	if caller != "" {
+		key = caller + "/" + key
+	}
+	return uuid.NewSHA1(idempotencyNamespace, []byte(fmt.Sprintf("%s/%d", key, index))).String()
+}
+
+// requestHash fingerprints payload. Maps are encoded with sorted keys, so equal
+// payloads hash the same.
+func requestHash(payload events.NotificationMessagePayload) (string, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Validate checks a payload without looking anything up
+func (s *IngestionService) Validate(payload events.NotificationMessagePayload) error {
+	if payload.UserID == "" {
+		return fmt.Errorf("%w: user_id is required", ErrInvalidPayload)
+	}
+
+	if _, err := domain.ParseRecipientSpec(payload.UserID); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	switch domain.NotificationType(payload.Channel) {
+	case domain.EmailNotification, domain.SMSNotification, domain.PushNotification, domain.InAppNotification:
+	default:
+		return fmt.Errorf("%w: unsupported channel %q", ErrInvalidPayload, payload.Channel)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	// Reject bad template data now instead of burning retries at send time
+	if payload.Template != nil && *payload.Template != "" && payload.Data != nil {
+		if err := domain_template.ValidateTemplateData(*payload.Template, *payload.Data); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+	}
+
+	return nil
+}
+
+// Ingest creates notification id from payload. Ingesting an id that already
+// exists returns the existing notification, so redelivered requests are safe,
+// unless it was created from another payload.
+func (s *IngestionService) Ingest(ctx context.Context, id string, payload events.NotificationMessagePayload) (*IngestResult, error) {
+	return s.ingest(ctx, id, payload, nil)
+}
+
+// ingest is Ingest for notifications created by campaignID, when set
+func (s *IngestionService) ingest(ctx context.Context, id string, payload events.NotificationMessagePayload, campaignID *string) (*IngestResult, error) {
+	hash, err := requestHash(payload)
+	if err != nil {
+		return nil, fmt.Errorf("notification[%s]: %w", id, err)
+	}
+
+	if result, err := s.duplicate(ctx, id, hash); result != nil || !errors.Is(err, domain.ErrNotificationNotFound) {
+		return result, err
+	}
+
+	if err := s.Validate(payload); err != nil {
+		return nil, fmt.Errorf("notification[%s]: rejected: %w", id, err)
+	}
+
+	spec, _ := domain.ParseRecipientSpec(payload.UserID)
+
+	userID, err := s.resolveUserID(ctx, spec)
+	if errors.Is(err, domain.ErrRecipientNotResolved) {
+		return nil, fmt.Errorf("notification[%s]: %w: %v", id, ErrInvalidPayload, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("notification[%s]: resolving %s failed: %w", id, spec, err)
+	}
+
+	// A user who is gone or blocked is recorded as rejected, without contact info
+	var rejectErr error
+	user, err := s.userDataSource.GetContactInfo(ctx, userID)
+	if domain.IsUndeliverableUser(err) {
+		rejectErr = err
+		user = &domain.UserContactInfo{}
+	} else if err != nil {
+		return nil, fmt.Errorf("notification[%s]: getContactInfo failed: %w, user: %s", id, err, userID)
+	}
+
+	// Convert to domain objects
+	recipient, err := domain.NewRecipient(
+		userID,
+		&user.Email,
+		user.Phone,
+		user.DeviceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("notification[%s]: %w: %v", id, ErrInvalidPayload, err)
+	}
+
+	if spec.IsSymbolic() {
+		raw := spec.String()
+		recipient.Spec = &raw
+	}
+
+	// The producer's locale wins over the one in the user's profile
+	locale := user.Locale
+	if payload.Locale != nil && *payload.Locale != "" {
+		locale = *payload.Locale
+	}
+
+	content, err := domain.NewContent(
+		payload.Subject,
+		payload.Message,
+		payload.Data,
+		payload.HTML,
+		payload.Template,
+		payload.Attachments,
+		locale,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("notification[%s]: %w: %v", id, ErrInvalidPayload, err)
+	}
+
+	isShell := 0
+	if payload.Type == "shell" {
+		isShell = 1
+	}
+
+	channel := domain.NotificationType(payload.Channel)
+
+	// An address that can't be delivered to won't get better with retries
+	if rejectErr == nil {
+		rejectErr = recipient.Normalize(channel, s.phoneCountry)
+	}
+
+	notification, err := domain.NewNotification(id, channel, *recipient, *content, s.notifications.retryPolicy(channel, content.Template).MaxAttempts, isShell)
+	if err != nil {
+		return nil, fmt.Errorf("notification[%s]: %w: %v", id, ErrInvalidPayload, err)
+	}
+	notification.CampaignID = campaignID
+	notification.RequestHash = &hash
+
+	if rejectErr != nil {
+		log.Printf("[Ingest] notification[%s]: undeliverable recipient for %s: %v", id, channel, rejectErr)
+		if err := s.notifications.reject(ctx, notification, rejectErr.Error()); err != nil {
+			return s.lostInsert(ctx, id, hash, err)
+		}
+		return &IngestResult{ID: id, Status: domain.StatusRejected, Reason: rejectErr.Error()}, nil
+	}
+
+	if err := s.notifications.enqueue(ctx, notification); err != nil {
+		return s.lostInsert(ctx, id, hash, err)
+	}
+
+	return &IngestResult{ID: id, Status: domain.StatusPending}, nil
+}
+
+// duplicate returns the stored notification id as a duplicate, or
+// domain.ErrNotificationNotFound when there is none. It fails with
+// ErrIdempotencyKeyReused when id was created from a request other than hash;
+// notifications stored before requests were hashed match any.
+func (s *IngestionService) duplicate(ctx context.Context, id, hash string) (*IngestResult, error) {
+	existing, err := s.repo.FindByID(ctx, id)
+	if errors.Is(err, domain.ErrNotificationNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("notification[%s]: lookup failed: %w", id, err)
+	}
+
+	if existing.RequestHash != nil && *existing.RequestHash != hash {
+		return nil, fmt.Errorf("notification[%s]: %w", id, ErrIdempotencyKeyReused)
+	}
+
+	return &IngestResult{ID: existing.ID, Status: existing.Status, Duplicate: true}, nil
+}
+
+// lostInsert handles a failed insert of notification id. A conflict means a
+// concurrent request with the same idempotency key created it first, which is
+// a duplicate rather than an error.
+func (s *IngestionService) lostInsert(ctx context.Context, id, hash string, err error) (*IngestResult, error) {
+	if !errors.Is(err, domain.ErrNotificationConflict) {
+		return nil, err
+	}
+
+	return s.duplicate(ctx, id, hash)
+}
+
+// resolveUserID turns a recipient specifier into the ID of the user it names
+func (s *IngestionService) resolveUserID(ctx context.Context, spec domain.RecipientSpec) (string, error) {
+	switch spec.Kind {
+	case domain.RecipientOrganizerOf:
+		return s.userDataSource.GetEventOrganizer(ctx, spec.Arg)
+	case domain.RecipientSystemUser:
+		return s.userDataSource.GetSystemUser(ctx)
+	case domain.RecipientReferrerOf:
+		return s.userDataSource.GetReferrer(ctx, spec.Arg)
+	default:
+		return spec.Arg, nil
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/events"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+)
+
+// storedRepo finds the one notification it holds
+type storedRepo struct {
+	ports.NotificationRepository
+	stored *domain.Notification
+}
+
+func (r *storedRepo) FindByID(ctx context.Context, id string) (*domain.Notification, error) {
+	if r.stored == nil || r.stored.ID != id {
+		return nil, domain.ErrNotificationNotFound
+	}
+	return r.stored, nil
+}
+
+func TestIdempotentIDIsScopedToCaller(t *testing.T) {
+	if IdempotentID("ticketing", "order-1", 0) != IdempotentID("ticketing", "order-1", 0) {
+		t.Error("the same caller and key gave different IDs")
+	}
+	if IdempotentID("ticketing", "order-1", 0) == IdempotentID("payments", "order-1", 0) {
+		t.Error("two callers share an ID for the same key")
+	}
+	if IdempotentID("ticketing", "order-1", 0) == IdempotentID("ticketing", "order-1", 1) {
+		t.Error("two batch items share an ID")
+	}
+}
+
+func TestIngestReplay(t *testing.T) {
+	message := "Your ticket is ready"
+	payload := events.NotificationMessagePayload{UserID: "user-1", Channel: "EMAIL", Subject: "Ticket", Message: &message}
+
+	changed := payload
+	changed.Subject = "Another ticket"
+
+	hash, err := requestHash(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		hash    *string
+		replay  events.NotificationMessagePayload
+		wantErr error
+	}{
+		{"same payload", &hash, payload, nil},
+		{"another payload", &hash, changed, ErrIdempotencyKeyReused},
+		{"stored before requests were hashed", nil, changed, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := IdempotentID("ticketing", "order-1", 0)
+			repo := &storedRepo{stored: &domain.Notification{ID: id, Status: domain.StatusSent, RequestHash: tt.hash}}
+			service := NewIngestionService(nil, repo, nil, "NG")
+
+			result, err := service.Ingest(context.Background(), id, tt.replay)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Ingest error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (!result.Duplicate || result.ID != id) {
+				t.Errorf("Ingest = %+v, want the stored notification as a duplicate", result)
+			}
+		})
+	}
+}
@@ -10,21 +10,33 @@ import (
 	"github.com/commitshark/notification-svc/internal/domain/ports"
 )
 
+// attemptLease is how long an attempt in progress keeps retry workers away
+// from its notification; an attempt that died is retried once it expires
+const attemptLease = 5 * time.Minute
+
 type NotificationService struct {
 	repo      ports.NotificationRepository
 	providers []ports.NotificationProvider
+	policies  domain.RetryPolicies
 }
 
 func NewNotificationService(
 	repo ports.NotificationRepository,
 	providers []ports.NotificationProvider,
+	policies domain.RetryPolicies,
 ) *NotificationService {
 	return &NotificationService{
 		repo:      repo,
 		providers: providers,
+		policies:  policies,
 	}
 }
 
+// retryPolicy returns the policy for notifications of channel and template
+func (s *NotificationService) retryPolicy(channel domain.NotificationType, template *string) domain.RetryPolicy {
+	return s.policies.For(channel, template)
+}
+
 // ProcessNotification processes incoming notification requests
 func (s *NotificationService) ProcessNotification(
 	ctx context.Context,
@@ -49,6 +61,14 @@ func (s *NotificationService) ProcessNotification(
 		return fmt.Errorf("failed to create notification: %w, notificationType: %s", err, notificationType)
 	}
 
+	return s.enqueue(ctx, notification)
+}
+
+// enqueue saves a new notification and sends it in the background
+func (s *NotificationService) enqueue(ctx context.Context, notification *domain.Notification) error {
+	// The send below is the first attempt, keep the retry worker off it
+	notification.Lease(attemptLease)
+
 	// Save to repository
 	if err := s.repo.Save(ctx, notification); err != nil {
 		return fmt.Errorf("failed to save notification: %w", err)
@@ -64,6 +84,105 @@ func (s *NotificationService) ProcessNotification(
 	return nil
 }
 
+func (s *NotificationService) GetNotification(ctx context.Context, id string) (*domain.Notification, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// ResendNotification sends a new copy of a notification, linked to the original
+func (s *NotificationService) ResendNotification(ctx context.Context, id, newID string) (*domain.Notification, error) {
+	original, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	copied, err := original.Resend(newID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy notification: %w", err)
+	}
+
+	if err := s.enqueue(ctx, copied); err != nil {
+		return nil, err
+	}
+
+	return copied, nil
+}
+
+// RequeueNotification resets the retries of a notification that gave up and
+// sends it again
+func (s *NotificationService) RequeueNotification(ctx context.Context, id string) error {
+	notification, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := notification.Requeue(); err != nil {
+		return err
+	}
+	notification.Lease(attemptLease)
+
+	if err := s.repo.Save(ctx, notification); err != nil {
+		return fmt.Errorf("failed to requeue notification: %w", err)
+	}
+
+	return s.SendNotification(ctx, id)
+}
+
+// CancelNotification stops a pending notification, or a failed one awaiting retry
+func (s *NotificationService) CancelNotification(ctx context.Context, id string) (*domain.Notification, error) {
+	notification, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := notification.Cancel(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, notification); err != nil {
+		return nil, fmt.Errorf("failed to cancel notification: %w", err)
+	}
+
+	return notification, nil
+}
+
+// RejectNotification records a notification that can never be delivered, e.g. one
+// with an invalid recipient address, as permanently failed without sending it
+func (s *NotificationService) RejectNotification(
+	ctx context.Context,
+	id string,
+	notificationType domain.NotificationType,
+	recipient domain.Recipient,
+	content domain.Content,
+	maxRetries, isMarketing int,
+	reason string,
+) error {
+	notification, err := domain.NewNotification(
+		id,
+		notificationType,
+		recipient,
+		content,
+		maxRetries,
+		isMarketing,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w, notificationType: %s", err, notificationType)
+	}
+
+	return s.reject(ctx, notification, reason)
+}
+
+// reject saves a new notification as permanently failed
+func (s *NotificationService) reject(ctx context.Context, notification *domain.Notification, reason string) error {
+	notification.MarkAsRejected(reason)
+
+	if err := s.repo.Save(ctx, notification); err != nil {
+		return fmt.Errorf("failed to save rejected notification: %w", err)
+	}
+
+	log.Printf("[RejectNotification] %s rejected: %s", notification.ID, reason)
+	return nil
+}
+
 // SendNotification attempts to send a notification
 func (s *NotificationService) SendNotification(ctx context.Context, notificationID string) error {
 	notification, err := s.repo.FindByID(ctx, notificationID)
@@ -85,7 +204,9 @@ func (s *NotificationService) SendNotification(ctx context.Context, notification
 	}
 
 	if provider == nil {
-		return fmt.Errorf("no provider supports notification type %s", notification.Type)
+		err := fmt.Errorf("no provider supports notification type %s", notification.Type)
+		s.recordFailure(ctx, notification, domain.PermanentFailure(err))
+		return err
 	}
 
 	log.Printf("[SendNotification] Send notification marketing (1/0) = %d Provider = %s", notification.IsMarketing, provider.Name())
@@ -93,10 +214,7 @@ func (s *NotificationService) SendNotification(ctx context.Context, notification
 	// Attempt to send
 	providerResponse, err := provider.Send(notification, notification.IsMarketing == 1)
 	if err != nil {
-		notification.MarkAsFailed(err.Error())
-		if saveErr := s.repo.Save(ctx, notification); saveErr != nil {
-			log.Printf("Failed to save failed notification: %v", saveErr)
-		}
+		s.recordFailure(ctx, notification, err)
 		return fmt.Errorf("failed to send notification: %w", err)
 	}
 
@@ -113,23 +231,41 @@ func (s *NotificationService) SendNotification(ctx context.Context, notification
 	return nil
 }
 
-// RetryFailedNotifications retries failed notifications with exponential backoff
+// recordFailure saves a failed attempt: permanent failures reject the notification,
+// throttled ones wait for the provider's retry-after and the rest are retried
+func (s *NotificationService) recordFailure(ctx context.Context, notification *domain.Notification, err error) {
+	kind, retryAfter := domain.ClassifyFailure(err)
+
+	if kind == domain.FailurePermanent {
+		notification.MarkAsRejected(err.Error())
+		log.Printf("[SendNotification] %s rejected: %v", notification.ID, err)
+	} else {
+		retryIn := s.retryPolicy(notification.Type, notification.Content.Template).Delay(notification.RetryCount)
+		if kind == domain.FailureRateLimited && retryAfter > retryIn {
+			retryIn = retryAfter
+		}
+		notification.MarkAsFailed(err.Error(), retryIn)
+	}
+
+	if saveErr := s.repo.Save(ctx, notification); saveErr != nil {
+		log.Printf("Failed to save failed notification: %v", saveErr)
+	}
+}
+
+// RetryFailedNotifications sends the notifications whose next attempt is due.
+// They are claimed first, so a later tick or another worker doesn't send them twice.
 func (s *NotificationService) RetryFailedNotifications(ctx context.Context, batchSize int) error {
-	pending, err := s.repo.FindPending(ctx, batchSize)
+	due, err := s.repo.ClaimPending(ctx, batchSize, time.Now().Add(attemptLease))
 	if err != nil {
-		return fmt.Errorf("failed to find pending notifications: %w", err)
+		return fmt.Errorf("failed to claim pending notifications: %w", err)
 	}
 
-	for _, notification := range pending {
-		// Calculate backoff: 5s * 2^retryCount
-		backoff := time.Duration(5*(1<<notification.RetryCount)) * time.Second
-
-		go func(n *domain.Notification, delay time.Duration) {
-			time.Sleep(delay)
-			if err := s.SendNotification(context.Background(), n.ID); err != nil {
-				log.Printf("Retry failed for notification %s: %v", n.ID, err)
+	for _, notification := range due {
+		go func(id string) {
+			if err := s.SendNotification(context.Background(), id); err != nil {
+				log.Printf("Retry failed for notification %s: %v", id, err)
 			}
-		}(notification, backoff)
+		}(notification.ID)
 	}
 
 	return nil
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/google/uuid"
+)
+
+type RetentionRunStatus string
+
+const (
+	RetentionRunning   RetentionRunStatus = "RUNNING"
+	RetentionCompleted RetentionRunStatus = "COMPLETED"
+	RetentionFailed    RetentionRunStatus = "FAILED"
+)
+
+const (
+	RetentionTriggerScheduled = "scheduled"
+	RetentionTriggerManual    = "manual"
+)
+
+const (
+	DefaultRetentionBatchSize = 500
+	// let the workers at the database between delete batches
+	retentionBatchPause = 100 * time.Millisecond
+)
+
+var ErrRetentionRunning = errors.New("retention run already in progress")
+
+// RetentionRun is a snapshot of a running or finished retention run
+type RetentionRun struct {
+	ID         string             `json:"id"`
+	Trigger    string             `json:"trigger"`
+	Status     RetentionRunStatus `json:"status"`
+	Archived   int                `json:"archived"`
+	Deleted    int                `json:"deleted"`
+	Archive    string             `json:"archive,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt *time.Time         `json:"finished_at,omitempty"`
+}
+
+// RetentionStatus reports the configured policies and the latest run
+type RetentionStatus struct {
+	Policies []domain.RetentionPolicy `json:"policies"`
+	LastRun  *RetentionRun            `json:"last_run"`
+}
+
+// RetentionService archives notifications matched by the retention policies,
+// deletes them in batches and compacts the store afterwards. Only one run
+// executes at a time; the last one is kept in memory.
+type RetentionService struct {
+	repo      ports.NotificationRepository
+	archive   ports.NotificationArchive
+	policies  []domain.RetentionPolicy
+	batchSize int
+
+	mu      sync.Mutex
+	lastRun *RetentionRun
+}
+
+func NewRetentionService(repo ports.NotificationRepository, archive ports.NotificationArchive, policies []domain.RetentionPolicy, batchSize int) *RetentionService {
+	if batchSize <= 0 {
+		batchSize = DefaultRetentionBatchSize
+	}
+
+	return &RetentionService{
+		repo:      repo,
+		archive:   archive,
+		policies:  policies,
+		batchSize: batchSize,
+	}
+}
+
+func (s *RetentionService) Status() RetentionStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := RetentionStatus{Policies: s.policies}
+	if s.lastRun != nil {
+		run := *s.lastRun
+		status.LastRun = &run
+	}
+
+	return status
+}
+
+// Start begins a run in the background, it outlives the request that started it
+func (s *RetentionService) Start(trigger string) (*RetentionRun, error) {
+	return s.start(context.Background(), trigger)
+}
+
+// Run starts a scheduled run every interval until ctx is done
+func (s *RetentionService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.start(ctx, RetentionTriggerScheduled); err != nil {
+				log.Printf("[Retention] skipped scheduled run: %v", err)
+			}
+		}
+	}
+}
+
+func (s *RetentionService) start(ctx context.Context, trigger string) (*RetentionRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastRun != nil && s.lastRun.Status == RetentionRunning {
+		return nil, ErrRetentionRunning
+	}
+
+	s.lastRun = &RetentionRun{
+		ID:        uuid.NewString(),
+		Trigger:   trigger,
+		Status:    RetentionRunning,
+		StartedAt: time.Now(),
+	}
+
+	go s.execute(ctx, s.lastRun.ID)
+
+	run := *s.lastRun
+	return &run, nil
+}
+
+func (s *RetentionService) execute(ctx context.Context, runID string) {
+	var writer ports.ArchiveWriter
+	defer func() {
+		if writer != nil {
+			if err := writer.Close(); err != nil {
+				log.Printf("[Retention] failed to close archive: %v", err)
+			}
+		}
+	}()
+
+	now := time.Now()
+	deleted := 0
+
+	for _, policy := range s.policies {
+		filter := policy.Filter(now)
+
+		for {
+			// Deleted rows drop out of the filter, so the first page is always the next batch
+			batch, _, err := s.repo.PaginatedList(ctx, 1, s.batchSize, filter)
+			if err != nil {
+				s.fail(runID, fmt.Errorf("failed to load %s notifications: %w", policy.Status, err))
+				return
+			}
+			if len(batch) == 0 {
+				break
+			}
+
+			if writer == nil {
+				writer, err = s.archive.Create("notifications-" + now.UTC().Format("20060102T150405Z"))
+				if err != nil {
+					s.fail(runID, err)
+					return
+				}
+				s.update(runID, func(r *RetentionRun) { r.Archive = writer.Location() })
+			}
+
+			ids := make([]string, len(batch))
+			for i, n := range batch {
+				ids[i] = n.ID
+			}
+
+			// Only the rows actually deleted are archived, and the deletion
+			// is rolled back unless they were archived
+			n, err := s.repo.DeleteByIDs(ctx, ids, filter, writer.Append)
+			if err != nil {
+				s.fail(runID, err)
+				return
+			}
+
+			deleted += n
+			s.update(runID, func(r *RetentionRun) {
+				r.Archived += n
+				r.Deleted += n
+			})
+
+			if len(batch) < s.batchSize {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				s.fail(runID, ctx.Err())
+				return
+			case <-time.After(retentionBatchPause):
+			}
+		}
+	}
+
+	if deleted > 0 {
+		if err := s.repo.Compact(ctx); err != nil {
+			s.fail(runID, err)
+			return
+		}
+	}
+
+	s.finish(runID, RetentionCompleted, "")
+	log.Printf("[Retention] run %s deleted %d notifications", runID, deleted)
+}
+
+func (s *RetentionService) fail(runID string, err error) {
+	s.finish(runID, RetentionFailed, err.Error())
+	log.Printf("[Retention] run %s failed: %v", runID, err)
+}
+
+func (s *RetentionService) finish(runID string, status RetentionRunStatus, errMsg string) {
+	s.update(runID, func(r *RetentionRun) {
+		now := time.Now()
+		r.Status = status
+		r.Error = errMsg
+		r.FinishedAt = &now
+	})
+}
+
+func (s *RetentionService) update(runID string, apply func(r *RetentionRun)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastRun != nil && s.lastRun.ID == runID {
+		apply(s.lastRun)
+	}
+}
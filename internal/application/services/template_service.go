@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	domain_template "github.com/commitshark/notification-svc/internal/domain/templates"
+)
+
+// layoutTemplate wraps every email and can be overridden like any other template
+const layoutTemplate = "layout"
+
+type TemplateService struct {
+	repo     ports.TemplateRepository
+	renderer ports.TemplateRenderer
+}
+
+func NewTemplateService(repo ports.TemplateRepository, renderer ports.TemplateRenderer) *TemplateService {
+	return &TemplateService{
+		repo:     repo,
+		renderer: renderer,
+	}
+}
+
+// PublishedVersions maps template names to their live stored version
+func (s *TemplateService) PublishedVersions(ctx context.Context) (map[string]int, error) {
+	published, err := s.repo.FindPublished(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]int, len(published))
+	for _, t := range published {
+		versions[t.Name] = t.Version
+	}
+	return versions, nil
+}
+
+// Published returns the live stored versions, bodies included, for senders
+// that render them
+func (s *TemplateService) Published(ctx context.Context) ([]*domain.TemplateVersion, error) {
+	return s.repo.FindPublished(ctx)
+}
+
+func (s *TemplateService) ListVersions(ctx context.Context, name string) ([]*domain.TemplateVersion, error) {
+	return s.repo.ListVersions(ctx, name)
+}
+
+func (s *TemplateService) GetVersion(ctx context.Context, name string, version int) (*domain.TemplateVersion, error) {
+	return s.repo.FindVersion(ctx, name, version)
+}
+
+// CreateDraft stores body as a new draft version of name
+func (s *TemplateService) CreateDraft(ctx context.Context, name, body string) (*domain.TemplateVersion, error) {
+	t, err := domain.NewTemplateVersion(name, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.check(t); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateVersion(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *TemplateService) UpdateDraft(ctx context.Context, name string, version int, body string) (*domain.TemplateVersion, error) {
+	t, err := s.repo.FindVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.UpdateBody(body); err != nil {
+		return nil, err
+	}
+
+	if err := s.check(t); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateVersion(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *TemplateService) DeleteDraft(ctx context.Context, name string, version int) error {
+	if _, err := s.repo.FindVersion(ctx, name, version); err != nil {
+		return err
+	}
+	return s.repo.DeleteVersion(ctx, name, version)
+}
+
+// Publish makes version live; publishing an older version is a rollback
+func (s *TemplateService) Publish(ctx context.Context, name string, version int) error {
+	if err := s.repo.Publish(ctx, name, version); err != nil {
+		return err
+	}
+
+	s.reload(ctx)
+	return nil
+}
+
+// Unpublish reverts name to its embedded copy
+func (s *TemplateService) Unpublish(ctx context.Context, name string) error {
+	if err := s.repo.Unpublish(ctx, name); err != nil {
+		return err
+	}
+
+	s.reload(ctx)
+	return nil
+}
+
+func (s *TemplateService) check(t *domain.TemplateVersion) error {
+	if base := t.BaseName(); base != layoutTemplate {
+		if _, ok := domain_template.Lookup(base); !ok {
+			return fmt.Errorf("%w: unknown template %s", domain.ErrInvalidTemplateKey, base)
+		}
+	}
+
+	if err := s.renderer.Check(t.Name, t.Body); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrTemplateInvalid, err)
+	}
+
+	return nil
+}
+
+// reload applies changes locally right away; other instances pick them up on their next poll
+func (s *TemplateService) reload(ctx context.Context) {
+	if err := s.renderer.Reload(ctx, s.repo); err != nil {
+		log.Printf("[TemplateService] reload error: %v", err)
+	}
+}
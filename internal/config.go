@@ -9,11 +9,26 @@ import (
 )
 
 type EmailSMTPConfig struct {
-	SMTPHost string `mapstructure:"smtp_host"`
-	SMTPPort int    `mapstructure:"smtp_port"`
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
-	From     string `mapstructure:"from"`
+	SMTPHost string     `mapstructure:"smtp_host"`
+	SMTPPort int        `mapstructure:"smtp_port"`
+	Username string     `mapstructure:"username"`
+	Password string     `mapstructure:"password"`
+	From     string     `mapstructure:"from"`
+	DKIM     DKIMConfig `mapstructure:"dkim"`
+}
+
+type DKIMKeyConfig struct {
+	Selector       string `mapstructure:"selector"`
+	PrivateKeyPath string `mapstructure:"private_key_path"`
+}
+
+// DKIMConfig enables signing when Domain is set. Selector/PrivateKeyPath is the
+// current key; Keys lists additional selectors signed alongside it during rotation.
+type DKIMConfig struct {
+	Domain         string          `mapstructure:"domain"`
+	Selector       string          `mapstructure:"selector"`
+	PrivateKeyPath string          `mapstructure:"private_key_path"`
+	Keys           []DKIMKeyConfig `mapstructure:"keys"`
 }
 
 type HttpEmailConfig struct {
@@ -21,30 +36,124 @@ type HttpEmailConfig struct {
 	APIKey string `mapstructure:"api_key"`
 }
 
+// IngestionConfig protects the HTTP ingestion API. It is disabled without an API key.
+type IngestionConfig struct {
+	APIKey string `mapstructure:"api_key"`
+}
+
 type ServiceConfig struct {
 	RetryBatchSize int           `mapstructure:"retry_batch_size"`
 	RetryInterval  time.Duration `mapstructure:"retry_interval"`
 }
 
+// RetryPolicyConfig configures retries of failed sends; unset fields take the
+// values of the policy it overrides
+type RetryPolicyConfig struct {
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	BaseDelay   time.Duration `mapstructure:"base_delay"`
+	MaxDelay    time.Duration `mapstructure:"max_delay"`
+	// Jitter is a pointer so 0 can turn jitter off rather than mean unset
+	Jitter *float64 `mapstructure:"jitter"`
+}
+
+// RetryConfig is the default retry policy, overridden per channel (EMAIL, SMS,
+// PUSH) and per template name
+type RetryConfig struct {
+	RetryPolicyConfig `mapstructure:",squash"`
+	Channels          map[string]RetryPolicyConfig `mapstructure:"channels"`
+	Templates         map[string]RetryPolicyConfig `mapstructure:"templates"`
+}
+
+// RetentionPolicyConfig purges notifications in Status older than MaxAge.
+// Category is "marketing", "transactional" or empty for both.
+type RetentionPolicyConfig struct {
+	Status   string        `mapstructure:"status"`
+	Category string        `mapstructure:"category"`
+	MaxAge   time.Duration `mapstructure:"max_age"`
+}
+
+// RetentionConfig archives and purges old notifications every Interval. The
+// scheduled job is disabled without policies.
+type RetentionConfig struct {
+	Interval   time.Duration           `mapstructure:"interval"`
+	BatchSize  int                     `mapstructure:"batch_size"`
+	ArchiveDir string                  `mapstructure:"archive_dir"`
+	Policies   []RetentionPolicyConfig `mapstructure:"policies"`
+}
+
 type KafkaConfig struct {
 	Brokers       []string `mapstructure:"brokers"`
 	Topic         string   `mapstructure:"topic"`
 	ConsumerGroup string   `mapstructure:"consumer_group"`
+	// UserEventsTopic carries user changes that invalidate cached contact info
+	UserEventsTopic string `mapstructure:"user_events_topic"`
+	// DeadLetterTopic receives messages that can't be processed. Without it
+	// invalid messages are dropped with a log line and transient failures are
+	// retried until they succeed.
+	DeadLetterTopic string `mapstructure:"dead_letter_topic"`
+}
+
+// UserCacheConfig bounds the contact info cache; a zero TTL disables it
+type UserCacheConfig struct {
+	TTL        time.Duration `mapstructure:"ttl"`
+	MaxEntries int           `mapstructure:"max_entries"`
+}
+
+// AttachmentsConfig limits the hosts attachments given by URL are fetched from.
+// Only https URLs are fetched, and none without allowed hosts.
+type AttachmentsConfig struct {
+	// AllowedHosts are exact host names; "*.example.com" allows its subdomains
+	AllowedHosts []string `mapstructure:"allowed_hosts"`
 }
 
 type SQLiteConfig struct {
 	Path string `mapstructure:"path"`
 }
 
+// EncryptionConfig points at the keyfile used to encrypt recipient details and
+// message content at rest. They are stored in plaintext without one.
+type EncryptionConfig struct {
+	KeyFile string `mapstructure:"key_file"`
+}
+
+type PostgresConfig struct {
+	DSN string `mapstructure:"dsn"`
+}
+
+// StorageConfig picks where notifications, campaigns and templates are stored:
+// "sqlite" (the default) or "postgres", which lets several workers share the load.
+type StorageConfig struct {
+	Backend string `mapstructure:"backend"`
+}
+
 type Config struct {
-	SQLite         SQLiteConfig    `mapstructure:"sqlite"`
-	Kafka          KafkaConfig     `mapstructure:"kafka"`
-	Email          EmailSMTPConfig `mapstructure:"email"`
-	MarketingEmail EmailSMTPConfig `mapstructure:"marketing_email"`
-	HTTPEmail      HttpEmailConfig `mapstructure:"http_email"`
-	Service        ServiceConfig   `mapstructure:"service"`
-	UserGrpcTarget string          `mapstructure:"user_grpc_target"`
-	HttpPort       int             `mapstructure:"http_port"`
+	Storage        StorageConfig     `mapstructure:"storage"`
+	SQLite         SQLiteConfig      `mapstructure:"sqlite"`
+	Postgres       PostgresConfig    `mapstructure:"postgres"`
+	Encryption     EncryptionConfig  `mapstructure:"encryption"`
+	Kafka          KafkaConfig       `mapstructure:"kafka"`
+	Email          EmailSMTPConfig   `mapstructure:"email"`
+	MarketingEmail EmailSMTPConfig   `mapstructure:"marketing_email"`
+	HTTPEmail      HttpEmailConfig   `mapstructure:"http_email"`
+	Attachments    AttachmentsConfig `mapstructure:"attachments"`
+	Ingestion      IngestionConfig   `mapstructure:"ingestion"`
+	UserCache      UserCacheConfig   `mapstructure:"user_cache"`
+	Service        ServiceConfig     `mapstructure:"service"`
+	Retry          RetryConfig       `mapstructure:"retry"`
+	Retention      RetentionConfig   `mapstructure:"retention"`
+	UserGrpcTarget string            `mapstructure:"user_grpc_target"`
+	// UserGrpcTimeout bounds each call to the user service
+	UserGrpcTimeout time.Duration `mapstructure:"user_grpc_timeout"`
+	HttpPort        int           `mapstructure:"http_port"`
+	GrpcPort        int           `mapstructure:"grpc_port"`
+	DefaultLocale   string        `mapstructure:"default_locale"`
+	// DefaultPhoneCountry is the ISO country of phone numbers in national format
+	DefaultPhoneCountry string `mapstructure:"default_phone_country"`
+	// TemplateReloadInterval is how often published templates are polled for changes
+	TemplateReloadInterval time.Duration `mapstructure:"template_reload_interval"`
+	// TemplateSourceURL is the worker endpoint the sender polls for published
+	// templates; the sender only uses the embedded ones without it
+	TemplateSourceURL string `mapstructure:"template_source_url"`
 }
 
 func LoadConfig() Config {
@@ -57,12 +166,21 @@ func LoadConfig() Config {
 
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
+	// Storage
+	viper.SetDefault("storage.backend", "sqlite")
+	_ = viper.BindEnv("storage.backend", "STORAGE_BACKEND")
+	_ = viper.BindEnv("postgres.dsn", "POSTGRES_DSN")
+	_ = viper.BindEnv("encryption.key_file", "ENCRYPTION_KEY_FILE")
+
 	// Transactional email
 	_ = viper.BindEnv("email.smtp_host", "EMAIL_SMTP_HOST")
 	_ = viper.BindEnv("email.smtp_port", "EMAIL_SMTP_PORT")
 	_ = viper.BindEnv("email.username", "EMAIL_USERNAME")
 	_ = viper.BindEnv("email.password", "EMAIL_PASSWORD")
 	_ = viper.BindEnv("email.from", "EMAIL_FROM")
+	_ = viper.BindEnv("email.dkim.domain", "EMAIL_DKIM_DOMAIN")
+	_ = viper.BindEnv("email.dkim.selector", "EMAIL_DKIM_SELECTOR")
+	_ = viper.BindEnv("email.dkim.private_key_path", "EMAIL_DKIM_PRIVATE_KEY_PATH")
 
 	// Marketing email
 	_ = viper.BindEnv("marketing_email.smtp_host", "MARKETING_EMAIL_SMTP_HOST")
@@ -70,13 +188,65 @@ func LoadConfig() Config {
 	_ = viper.BindEnv("marketing_email.username", "MARKETING_EMAIL_USERNAME")
 	_ = viper.BindEnv("marketing_email.password", "MARKETING_EMAIL_PASSWORD")
 	_ = viper.BindEnv("marketing_email.from", "MARKETING_EMAIL_FROM")
+	_ = viper.BindEnv("marketing_email.dkim.domain", "MARKETING_EMAIL_DKIM_DOMAIN")
+	_ = viper.BindEnv("marketing_email.dkim.selector", "MARKETING_EMAIL_DKIM_SELECTOR")
+	_ = viper.BindEnv("marketing_email.dkim.private_key_path", "MARKETING_EMAIL_DKIM_PRIVATE_KEY_PATH")
 
 	// HTTP email
 	_ = viper.BindEnv("http_email.api_key", "HTTP_EMAIL_API_KEY")
 
+	// Attachments, e.g. "cdn.eventor.com.ng,*.storage.example.com"
+	_ = viper.BindEnv("attachments.allowed_hosts", "ATTACHMENTS_ALLOWED_HOSTS")
+
+	// HTTP ingestion
+	_ = viper.BindEnv("ingestion.api_key", "INGESTION_API_KEY")
+
 	// HTTP port
 	_ = viper.BindEnv("http_port", "HTTP_PORT")
 
+	// gRPC port
+	viper.SetDefault("grpc_port", 50051)
+	_ = viper.BindEnv("grpc_port", "GRPC_PORT")
+
+	// User service
+	viper.SetDefault("user_grpc_timeout", 3*time.Second)
+	_ = viper.BindEnv("user_grpc_timeout", "USER_GRPC_TIMEOUT")
+
+	// User contact info cache
+	viper.SetDefault("user_cache.ttl", 5*time.Minute)
+	_ = viper.BindEnv("user_cache.ttl", "USER_CACHE_TTL")
+	viper.SetDefault("user_cache.max_entries", 10000)
+	_ = viper.BindEnv("user_cache.max_entries", "USER_CACHE_MAX_ENTRIES")
+	_ = viper.BindEnv("kafka.user_events_topic", "KAFKA_USER_EVENTS_TOPIC")
+	_ = viper.BindEnv("kafka.dead_letter_topic", "KAFKA_DEAD_LETTER_TOPIC")
+
+	// Retries
+	viper.SetDefault("retry.max_attempts", 3)
+	_ = viper.BindEnv("retry.max_attempts", "RETRY_MAX_ATTEMPTS")
+	viper.SetDefault("retry.base_delay", 5*time.Second)
+	_ = viper.BindEnv("retry.base_delay", "RETRY_BASE_DELAY")
+	viper.SetDefault("retry.max_delay", 30*time.Minute)
+	_ = viper.BindEnv("retry.max_delay", "RETRY_MAX_DELAY")
+	viper.SetDefault("retry.jitter", 0.2)
+	_ = viper.BindEnv("retry.jitter", "RETRY_JITTER")
+
+	// Retention
+	viper.SetDefault("retention.interval", 24*time.Hour)
+	_ = viper.BindEnv("retention.interval", "RETENTION_INTERVAL")
+	viper.SetDefault("retention.batch_size", 500)
+	_ = viper.BindEnv("retention.batch_size", "RETENTION_BATCH_SIZE")
+	viper.SetDefault("retention.archive_dir", "archive")
+	_ = viper.BindEnv("retention.archive_dir", "RETENTION_ARCHIVE_DIR")
+
+	// Templates
+	viper.SetDefault("default_locale", "en")
+	_ = viper.BindEnv("default_locale", "DEFAULT_LOCALE")
+	viper.SetDefault("default_phone_country", "NG")
+	_ = viper.BindEnv("default_phone_country", "DEFAULT_PHONE_COUNTRY")
+	viper.SetDefault("template_reload_interval", 30*time.Second)
+	_ = viper.BindEnv("template_reload_interval", "TEMPLATE_RELOAD_INTERVAL")
+	_ = viper.BindEnv("template_source_url", "TEMPLATE_SOURCE_URL")
+
 	if err := viper.ReadInConfig(); err == nil {
 		log.Println("Loaded config file:", viper.ConfigFileUsed())
 	} else {
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// DefaultPhoneCountry is used for phone numbers written in national format
+const DefaultPhoneCountry = "NG"
+
+var ErrInvalidAddress = errors.New("invalid recipient address")
+
+// countryCallingCodes covers the markets we send SMS to
+var countryCallingCodes = map[string]string{
+	"NG": "234",
+	"GH": "233",
+	"KE": "254",
+	"ZA": "27",
+	"RW": "250",
+	"UG": "256",
+	"TZ": "255",
+	"CM": "237",
+	"SN": "221",
+	"CI": "225",
+	"EG": "20",
+	"GB": "44",
+	"US": "1",
+	"CA": "1",
+	"FR": "33",
+	"DE": "49",
+	"AE": "971",
+}
+
+var (
+	e164Pattern      = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	phoneSeparators  = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", " ", "")
+	emailDomainLabel = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+)
+
+// NormalizeEmail validates an address and returns it lower-cased with the domain
+// in its ASCII (punycode) form, e.g. "Ada@Bücher.de" -> "ada@xn--bcher-kva.de".
+func NormalizeEmail(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("%w: empty email", ErrInvalidAddress)
+	}
+
+	parsed, err := mail.ParseAddress(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q is not an email address", ErrInvalidAddress, raw)
+	}
+
+	at := strings.LastIndex(parsed.Address, "@")
+	if at <= 0 || at == len(parsed.Address)-1 {
+		return "", fmt.Errorf("%w: %q is not an email address", ErrInvalidAddress, raw)
+	}
+	local, host := parsed.Address[:at], parsed.Address[at+1:]
+
+	host, err = idna.Lookup.ToASCII(strings.TrimSuffix(host, "."))
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid domain in %q", ErrInvalidAddress, raw)
+	}
+	host = strings.ToLower(host)
+
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return "", fmt.Errorf("%w: %q has no top-level domain", ErrInvalidAddress, raw)
+	}
+	for _, l := range labels {
+		if !emailDomainLabel.MatchString(l) {
+			return "", fmt.Errorf("%w: invalid domain in %q", ErrInvalidAddress, raw)
+		}
+	}
+
+	if len(local) > 64 {
+		return "", fmt.Errorf("%w: local part of %q is too long", ErrInvalidAddress, raw)
+	}
+
+	return strings.ToLower(local) + "@" + host, nil
+}
+
+// NormalizePhone returns the E.164 form of a phone number. Numbers without an
+// international prefix are read in the national format of defaultCountry, e.g.
+// "0803 123 4567" with NG -> "+2348031234567".
+func NormalizePhone(raw, defaultCountry string) (string, error) {
+	number := phoneSeparators.Replace(strings.TrimSpace(raw))
+	if number == "" {
+		return "", fmt.Errorf("%w: empty phone number", ErrInvalidAddress)
+	}
+
+	switch {
+	case strings.HasPrefix(number, "+"):
+	case strings.HasPrefix(number, "00"):
+		number = "+" + number[2:]
+	default:
+		if defaultCountry == "" {
+			defaultCountry = DefaultPhoneCountry
+		}
+		code, ok := countryCallingCodes[strings.ToUpper(defaultCountry)]
+		if !ok {
+			return "", fmt.Errorf("%w: unsupported default country %s", ErrInvalidAddress, defaultCountry)
+		}
+
+		// Drop the national trunk prefix; numbers already starting with the
+		// calling code (e.g. "234803...") are kept as they are
+		if strings.HasPrefix(number, "0") {
+			number = "+" + code + strings.TrimPrefix(number, "0")
+		} else if strings.HasPrefix(number, code) && len(number) > len(code)+7 {
+			number = "+" + number
+		} else {
+			number = "+" + code + number
+		}
+	}
+
+	if !e164Pattern.MatchString(number) {
+		return "", fmt.Errorf("%w: %q is not a valid phone number", ErrInvalidAddress, raw)
+	}
+
+	return number, nil
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string // empty when invalid
+	}{
+		{"ada@example.com", "ada@example.com"},
+		{"  Ada@Example.COM ", "ada@example.com"},
+		{"Ada Lovelace <Ada@Example.com>", "ada@example.com"},
+		{"ada@example.com.", ""},
+		{"ada+tickets@mail.example.co.uk", "ada+tickets@mail.example.co.uk"},
+		{"Ada@Bücher.de", "ada@xn--bcher-kva.de"},
+		{"ada@XN--BCHER-KVA.de", "ada@xn--bcher-kva.de"},
+		{strings.Repeat("a", 64) + "@example.com", strings.Repeat("a", 64) + "@example.com"},
+		{"", ""},
+		{"   ", ""},
+		{"ada", ""},
+		{"ada@", ""},
+		{"@example.com", ""},
+		{"ada@localhost", ""},
+		{"ada@-example.com", ""},
+		{"ada@example-.com", ""},
+		{"ada@exa_mple.com", ""},
+		{"ada@example..com", ""},
+		{"ada@@example.com", ""},
+		{strings.Repeat("a", 65) + "@example.com", ""},
+		{"ada@example.com, bob@example.com", ""},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeEmail(tt.raw)
+		if tt.want == "" {
+			if !errors.Is(err, ErrInvalidAddress) {
+				t.Errorf("NormalizeEmail(%q) = %q, %v, want ErrInvalidAddress", tt.raw, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeEmail(%q) = %q, %v, want %q", tt.raw, got, err, tt.want)
+		}
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		raw     string
+		country string
+		want    string // empty when invalid
+	}{
+		{"+2348031234567", "", "+2348031234567"},
+		{"+234 803-123-4567", "", "+2348031234567"},
+		{"00234 803 123 4567", "", "+2348031234567"},
+		{"0803 123 4567", "", "+2348031234567"},
+		{"0803 123 4567", "ng", "+2348031234567"},
+		{"2348031234567", "NG", "+2348031234567"},
+		{"8031234567", "NG", "+2348031234567"},
+		{"(0803) 123.4567", "NG", "+2348031234567"},
+		{"024 412 3456", "GH", "+233244123456"},
+		{"020 7946 0958", "GB", "+442079460958"},
+		{"(415) 555-2671", "US", "+14155552671"},
+		{"", "NG", ""},
+		{"0803", "NG", ""},
+		{"+0123456789", "", ""},
+		{"+1234567890123456", "", ""},
+		{"call me", "NG", ""},
+		{"0803 123 4567", "ZZ", ""},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.raw, tt.country)
+		if tt.want == "" {
+			if !errors.Is(err, ErrInvalidAddress) {
+				t.Errorf("NormalizePhone(%q, %q) = %q, %v, want ErrInvalidAddress", tt.raw, tt.country, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizePhone(%q, %q) = %q, %v, want %q", tt.raw, tt.country, got, err, tt.want)
+		}
+	}
+}
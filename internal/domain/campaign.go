@@ -0,0 +1,257 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type CampaignStatus string
+
+const (
+	CampaignDraft     CampaignStatus = "DRAFT"
+	CampaignScheduled CampaignStatus = "SCHEDULED"
+	CampaignSending   CampaignStatus = "SENDING"
+	CampaignPaused    CampaignStatus = "PAUSED"
+	CampaignCompleted CampaignStatus = "COMPLETED"
+	CampaignCancelled CampaignStatus = "CANCELLED"
+)
+
+const (
+	DefaultCampaignRate = 20  // notifications per second
+	MaxCampaignRate     = 200 // notifications per second
+)
+
+var (
+	ErrCampaignNotFound   = errors.New("campaign not found")
+	ErrCampaignInvalid    = errors.New("invalid campaign")
+	ErrCampaignTransition = errors.New("campaign cannot change to that status")
+	ErrCampaignConflict   = errors.New("campaign was modified concurrently")
+)
+
+// CampaignAudience lists the users a campaign is sent to
+type CampaignAudience struct {
+	UserIDs []string `json:"user_ids"`
+}
+
+// Campaign is a marketing broadcast of one template to an audience. Its
+// notifications are created in batches as the campaign progresses.
+type Campaign struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name"`
+	Channel  NotificationType       `json:"channel"`
+	Template string                 `json:"template"`
+	Subject  string                 `json:"subject"`
+	Locale   string                 `json:"locale,omitempty"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+	Audience CampaignAudience       `json:"audience"`
+	// RatePerSecond caps how many notifications are created per second
+	RatePerSecond int            `json:"rate_per_second"`
+	Status        CampaignStatus `json:"status"`
+	ScheduledAt   *time.Time     `json:"scheduled_at,omitempty"`
+	// Cursor is the index of the next audience member to notify
+	Cursor      int        `json:"cursor"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Version     int        `json:"version"`
+}
+
+func NewCampaign(
+	id, name string,
+	channel NotificationType,
+	template, subject, locale string,
+	data map[string]interface{},
+	userIDs []string,
+	ratePerSecond int,
+) (*Campaign, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id cannot be empty", ErrCampaignInvalid)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrCampaignInvalid)
+	}
+
+	if channel == "" {
+		channel = EmailNotification
+	}
+	if !isValidNotificationType(channel) {
+		return nil, fmt.Errorf("%w: unsupported channel %q", ErrCampaignInvalid, channel)
+	}
+
+	if template == "" {
+		return nil, fmt.Errorf("%w: template cannot be empty", ErrCampaignInvalid)
+	}
+
+	if subject == "" {
+		return nil, fmt.Errorf("%w: subject cannot be empty", ErrCampaignInvalid)
+	}
+
+	audience := dedupeUserIDs(userIDs)
+	if len(audience) == 0 {
+		return nil, fmt.Errorf("%w: audience cannot be empty", ErrCampaignInvalid)
+	}
+
+	if ratePerSecond <= 0 {
+		ratePerSecond = DefaultCampaignRate
+	}
+	if ratePerSecond > MaxCampaignRate {
+		ratePerSecond = MaxCampaignRate
+	}
+
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	now := time.Now()
+	return &Campaign{
+		ID:            id,
+		Name:          name,
+		Channel:       channel,
+		Template:      template,
+		Subject:       subject,
+		Locale:        NormalizeLocale(locale),
+		Data:          data,
+		Audience:      CampaignAudience{UserIDs: audience},
+		RatePerSecond: ratePerSecond,
+		Status:        CampaignDraft,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Version:       1,
+	}, nil
+}
+
+func dedupeUserIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
+
+func (c *Campaign) Total() int {
+	return len(c.Audience.UserIDs)
+}
+
+// IsFinished reports whether the campaign can no longer send
+func (c *Campaign) IsFinished() bool {
+	return c.Status == CampaignCompleted || c.Status == CampaignCancelled
+}
+
+// IsDue reports whether the dispatcher should send the campaign's next batch
+func (c *Campaign) IsDue(now time.Time) bool {
+	switch c.Status {
+	case CampaignSending:
+		return true
+	case CampaignScheduled:
+		return c.ScheduledAt == nil || !c.ScheduledAt.After(now)
+	default:
+		return false
+	}
+}
+
+// Launch starts a draft campaign now, or schedules it when at is in the future
+func (c *Campaign) Launch(at *time.Time, now time.Time) error {
+	if c.Status != CampaignDraft {
+		return fmt.Errorf("%w: %s campaigns cannot be launched", ErrCampaignTransition, c.Status)
+	}
+
+	if at != nil && at.After(now) {
+		c.ScheduledAt = at
+		c.Status = CampaignScheduled
+	} else {
+		c.start(now)
+	}
+
+	c.touch(now)
+	return nil
+}
+
+// Start moves a scheduled campaign that is due to sending
+func (c *Campaign) Start(now time.Time) {
+	if c.Status == CampaignScheduled && c.IsDue(now) {
+		c.start(now)
+		c.touch(now)
+	}
+}
+
+func (c *Campaign) start(now time.Time) {
+	c.Status = CampaignSending
+	if c.StartedAt == nil {
+		c.StartedAt = &now
+	}
+}
+
+func (c *Campaign) Pause(now time.Time) error {
+	if c.Status != CampaignSending && c.Status != CampaignScheduled {
+		return fmt.Errorf("%w: %s campaigns cannot be paused", ErrCampaignTransition, c.Status)
+	}
+
+	c.Status = CampaignPaused
+	c.touch(now)
+	return nil
+}
+
+// Resume continues a paused campaign, waiting for its schedule if it never started
+func (c *Campaign) Resume(now time.Time) error {
+	if c.Status != CampaignPaused {
+		return fmt.Errorf("%w: %s campaigns cannot be resumed", ErrCampaignTransition, c.Status)
+	}
+
+	if c.StartedAt == nil && c.ScheduledAt != nil && c.ScheduledAt.After(now) {
+		c.Status = CampaignScheduled
+	} else {
+		c.start(now)
+	}
+
+	c.touch(now)
+	return nil
+}
+
+func (c *Campaign) Cancel(now time.Time) error {
+	if c.IsFinished() {
+		return fmt.Errorf("%w: %s campaigns cannot be cancelled", ErrCampaignTransition, c.Status)
+	}
+
+	c.Status = CampaignCancelled
+	c.touch(now)
+	return nil
+}
+
+// NextBatch returns the user IDs to notify next, at most limit of them
+func (c *Campaign) NextBatch(limit int) []string {
+	end := c.Cursor + limit
+	if end > c.Total() {
+		end = c.Total()
+	}
+	return c.Audience.UserIDs[c.Cursor:end]
+}
+
+// Advance records n more audience members as notified, completing a sending
+// campaign once everyone has been
+func (c *Campaign) Advance(n int, now time.Time) {
+	c.Cursor += n
+	if c.Cursor >= c.Total() {
+		c.Cursor = c.Total()
+		if c.Status == CampaignSending {
+			c.Status = CampaignCompleted
+			c.CompletedAt = &now
+		}
+	}
+	c.touch(now)
+}
+
+func (c *Campaign) touch(now time.Time) {
+	c.UpdatedAt = now
+	c.Version++
+}
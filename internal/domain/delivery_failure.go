@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// FailureKind says whether sending a notification again can succeed
+type FailureKind string
+
+const (
+	// FailureTransient failures, e.g. timeouts, are retried with backoff
+	FailureTransient FailureKind = "TRANSIENT"
+	// FailurePermanent failures, e.g. a missing template, fail the same way on every attempt
+	FailurePermanent FailureKind = "PERMANENT"
+	// FailureRateLimited failures are retried once the provider's retry-after has passed
+	FailureRateLimited FailureKind = "RATE_LIMITED"
+)
+
+// DeliveryError is a send failure classified by providers and renderers
+type DeliveryError struct {
+	Kind FailureKind
+	// RetryAfter is the provider's throttling hint, 0 when it gave none
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *DeliveryError) Error() string { return e.Err.Error() }
+
+func (e *DeliveryError) Unwrap() error { return e.Err }
+
+// PermanentFailure marks err as one retrying can't fix
+func PermanentFailure(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &DeliveryError{Kind: FailurePermanent, Err: err}
+}
+
+// RateLimitedFailure marks err as throttling, to be retried after retryAfter
+func RateLimitedFailure(err error, retryAfter time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &DeliveryError{Kind: FailureRateLimited, RetryAfter: retryAfter, Err: err}
+}
+
+// ClassifyFailure returns the kind of a send failure and any retry-after hint.
+// Errors nobody classified are treated as transient.
+func ClassifyFailure(err error) (FailureKind, time.Duration) {
+	var deliveryErr *DeliveryError
+	if errors.As(err, &deliveryErr) {
+		return deliveryErr.Kind, deliveryErr.RetryAfter
+	}
+	return FailureTransient, 0
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type TemplateStatus string
+
+const (
+	TemplateDraft     TemplateStatus = "DRAFT"
+	TemplatePublished TemplateStatus = "PUBLISHED"
+	TemplateArchived  TemplateStatus = "ARCHIVED"
+)
+
+// "welcome", "welcome.fr", "layout.pt-br"
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*(\.[a-z]{2,3}(-[a-z0-9]+)*)?$`)
+
+var (
+	ErrTemplateNotFound   = errors.New("template version not found")
+	ErrTemplateNotDraft   = errors.New("only draft template versions can be changed")
+	ErrTemplateBodyEmpty  = errors.New("template body cannot be empty")
+	ErrTemplateInvalid    = errors.New("template does not parse")
+	ErrInvalidTemplateKey = errors.New("invalid template name")
+)
+
+// TemplateVersion is a stored revision of an email template file. Name is the file
+// stem used by the embedded templates, e.g. "welcome" or "welcome.fr". At most one
+// version of a name is published; publishing an older version rolls back.
+type TemplateVersion struct {
+	Name        string         `json:"name"`
+	Version     int            `json:"version"`
+	Body        string         `json:"body"`
+	Status      TemplateStatus `json:"status"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	PublishedAt *time.Time     `json:"published_at,omitempty"`
+}
+
+func NewTemplateVersion(name, body string) (*TemplateVersion, error) {
+	if !IsValidTemplateName(name) {
+		return nil, ErrInvalidTemplateKey
+	}
+	if strings.TrimSpace(body) == "" {
+		return nil, ErrTemplateBodyEmpty
+	}
+
+	now := time.Now().UTC()
+	return &TemplateVersion{
+		Name:      name,
+		Body:      body,
+		Status:    TemplateDraft,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// UpdateBody replaces the body of a draft
+func (t *TemplateVersion) UpdateBody(body string) error {
+	if t.Status != TemplateDraft {
+		return ErrTemplateNotDraft
+	}
+	if strings.TrimSpace(body) == "" {
+		return ErrTemplateBodyEmpty
+	}
+
+	t.Body = body
+	t.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// BaseName returns the template name without its locale suffix
+func (t *TemplateVersion) BaseName() string {
+	base, _, _ := strings.Cut(t.Name, ".")
+	return base
+}
+
+func IsValidTemplateName(name string) bool {
+	return templateNamePattern.MatchString(name)
+}
@@ -3,28 +3,37 @@ package events
 import (
 	"encoding/json"
 	"fmt"
+
+	"github.com/commitshark/notification-svc/internal/domain"
 )
 
 type NotificationMessagePayload struct {
 	Type    string `json:"type"` // e.g. "ticket.created"
 	Channel string `json:"channel"`
-	UserID  string `json:"user_id"` // the user being notified
+	UserID  string `json:"user_id"` // the user being notified, or organizer_of:<event>, system_user, referrer_of:<user_id>
 
 	Subject  string  `json:"subject"`
 	Message  *string `json:"message,omitempty"`  // plain text body
 	HTML     *string `json:"html,omitempty"`     // HTML email body
 	Template *string `json:"template,omitempty"` // optional template ID
+	Locale   *string `json:"locale,omitempty"`   // e.g. "en", "fr-CA"; defaults to the user's, then the service locale
 
 	Data *map[string]any `json:"data,omitempty"` // metadata payload
+
+	Attachments []domain.Attachment `json:"attachments,omitempty"` // files sent with the notification
 }
 
 func (m *NotificationMessagePayload) Validate() error {
-	if m.Subject == "" {
+	// Templates carry a default subject in their catalog
+	if m.Subject == "" && (m.Template == nil || *m.Template == "") {
 		return fmt.Errorf("content.title is required")
 	}
 	if (m.Message == nil || *m.Message == "") && (m.Data == nil) {
 		return fmt.Errorf("content.body and content.data cannot be empty")
 	}
+	if err := domain.ValidateAttachments(m.Attachments); err != nil {
+		return fmt.Errorf("content.attachments: %w", err)
+	}
 	return nil
 }
 
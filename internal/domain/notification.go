@@ -10,6 +10,15 @@ type NotificationFilter struct {
 	IsMarketing *bool               `json:"is_marketing"`
 	Status      *NotificationStatus `json:"status"`
 	Query       string              `json:"q"`
+	Template    *string             `json:"template,omitempty"`
+	// Recipient matches the recipient id, email or phone exactly
+	Recipient     string     `json:"recipient,omitempty"`
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+	CampaignID    *string    `json:"campaign_id,omitempty"`
+	// Settled leaves out failed notifications that still have retries left,
+	// i.e. those the retry worker has scheduled for another attempt
+	Settled bool `json:"-"`
 }
 
 type Notification struct {
@@ -25,8 +34,28 @@ type Notification struct {
 	MaxRetries       int                `json:"max_retries"`
 	Version          int                `json:"version"`
 	IsMarketing      int                `json:"is_marketing"`
+	// TemplateVersion is the stored template version the email was rendered with,
+	// 0 for the embedded copy
+	TemplateVersion int `json:"template_version,omitempty"`
+	// ResentFrom links a resend to the notification it copies
+	ResentFrom *string `json:"resent_from,omitempty"`
+	// CampaignID is set on notifications created by a campaign
+	CampaignID *string `json:"campaign_id,omitempty"`
+	// NextAttemptAt is when the next attempt is due, nil for right away. It is
+	// also pushed back while an attempt is in progress.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	// RequestHash fingerprints the request the notification was created from,
+	// so a replayed idempotency key with another payload can be told apart
+	RequestHash *string `json:"-"`
 }
 
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	// ErrNotificationConflict means the notification changed since it was loaded
+	ErrNotificationConflict = errors.New("optimistic locking conflict")
+	ErrNotCancellable       = errors.New("only pending or retryable failed notifications can be cancelled")
+)
+
 // Business rules
 func (n *Notification) CanBeSent() bool {
 	return n.Status == StatusPending ||
@@ -42,16 +71,91 @@ func (n *Notification) MarkAsSent(providerResponse string) error {
 	n.Status = StatusSent
 	n.SentAt = &now
 	n.ProviderResponse = providerResponse
+	n.NextAttemptAt = nil
 	n.Version++
 
 	return nil
 }
 
-func (n *Notification) MarkAsFailed(providerResponse string) {
+// RecordTemplateVersion notes the template version the provider rendered
+func (n *Notification) RecordTemplateVersion(version int) {
+	n.TemplateVersion = version
+}
+
+// MarkAsFailed records a failed attempt and schedules the next one retryIn from now
+func (n *Notification) MarkAsFailed(providerResponse string, retryIn time.Duration) {
 	n.Status = StatusFailed
 	n.RetryCount++
 	n.ProviderResponse = providerResponse
+
+	next := time.Now().Add(retryIn)
+	n.NextAttemptAt = &next
+	n.Version++
+}
+
+// Lease reserves the notification for an attempt in progress: retry workers
+// skip it until the lease expires, or pick it up again if the attempt died.
+func (n *Notification) Lease(d time.Duration) {
+	until := time.Now().Add(d)
+	n.NextAttemptAt = &until
+}
+
+// MarkAsRejected fails the notification for good, for problems that sending
+// again can't fix
+func (n *Notification) MarkAsRejected(reason string) {
+	n.Status = StatusRejected
+	n.ProviderResponse = reason
+	n.NextAttemptAt = nil
+	n.Version++
+}
+
+// Cancel stops a notification that hasn't been sent yet, including failed ones
+// still waiting for a retry
+func (n *Notification) Cancel() error {
+	if !n.CanBeSent() {
+		return ErrNotCancellable
+	}
+
+	n.Status = StatusCancelled
+	n.ProviderResponse = "cancelled"
 	n.Version++
+	return nil
+}
+
+var ErrNotRequeueable = errors.New("only rejected notifications and failed ones out of retries can be requeued")
+
+// RequeueableStatuses are the statuses Requeue may accept
+var RequeueableStatuses = []NotificationStatus{StatusFailed, StatusRejected}
+
+// Requeue resets the retry budget of a failed notification that ran out of
+// retries. Rejected ones can be requeued once the cause, e.g. a missing
+// template, is fixed. Pending notifications and failed ones with retries left
+// belong to the retry worker, which may be sending them right now.
+func (n *Notification) Requeue() error {
+	switch {
+	case n.Status == StatusRejected:
+	case n.Status == StatusFailed && !n.CanBeSent():
+	default:
+		return ErrNotRequeueable
+	}
+
+	n.Status = StatusPending
+	n.RetryCount = 0
+	n.NextAttemptAt = nil
+	n.Version++
+	return nil
+}
+
+// Resend returns a new pending notification with the same recipient and content
+func (n *Notification) Resend(id string) (*Notification, error) {
+	copied, err := NewNotification(id, n.Type, n.Recipient, n.Content, n.MaxRetries, n.IsMarketing)
+	if err != nil {
+		return nil, err
+	}
+
+	source := n.ID
+	copied.ResentFrom = &source
+	return copied, nil
 }
 
 func (n *Notification) MarkAsDelivered() error {
@@ -84,7 +188,7 @@ func NewNotification(
 	}
 
 	if maxRetries < 0 {
-		maxRetries = 3 // default
+		maxRetries = DefaultRetryPolicy.MaxAttempts
 	}
 
 	return &Notification{
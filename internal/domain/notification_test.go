@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNotificationRequeue(t *testing.T) {
+	email := "a@example.com"
+	newNotification := func(t *testing.T) *Notification {
+		n, err := NewNotification("n1", EmailNotification, Recipient{ID: "u1", Email: &email}, Content{Title: "Hi"}, 2, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	tests := []struct {
+		name  string
+		setup func(n *Notification)
+		ok    bool
+	}{
+		{"pending", func(n *Notification) {}, false},
+		{"leased", func(n *Notification) { n.Lease(time.Minute) }, false},
+		{"failed with retries left", func(n *Notification) { n.MarkAsFailed("timeout", time.Minute) }, false},
+		{"failed out of retries", func(n *Notification) {
+			n.MarkAsFailed("timeout", time.Minute)
+			n.MarkAsFailed("timeout", time.Minute)
+		}, true},
+		{"rejected", func(n *Notification) { n.MarkAsRejected("no template") }, true},
+		{"sent", func(n *Notification) { n.MarkAsSent("ok") }, false},
+		{"cancelled", func(n *Notification) { n.Cancel() }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := newNotification(t)
+			tt.setup(n)
+
+			err := n.Requeue()
+			if !tt.ok {
+				if !errors.Is(err, ErrNotRequeueable) {
+					t.Fatalf("Requeue: err = %v, want ErrNotRequeueable", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Requeue: %v", err)
+			}
+			if n.Status != StatusPending || n.RetryCount != 0 || n.NextAttemptAt != nil {
+				t.Errorf("after Requeue: status %s, retries %d, next attempt %v", n.Status, n.RetryCount, n.NextAttemptAt)
+			}
+		})
+	}
+}
@@ -16,4 +16,7 @@ const (
 	StatusSent      NotificationStatus = "SENT"
 	StatusFailed    NotificationStatus = "FAILED"
 	StatusDelivered NotificationStatus = "DELIVERED"
+	StatusCancelled NotificationStatus = "CANCELLED"
+	// StatusRejected is final: the notification can't be delivered however often it's retried
+	StatusRejected NotificationStatus = "REJECTED"
 )
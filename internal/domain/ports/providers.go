@@ -1,6 +1,10 @@
 package ports
 
-import "github.com/commitshark/notification-svc/internal/domain"
+import (
+	"context"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
 
 type NotificationProvider interface {
 	Send(notification *domain.Notification, isMarketing bool) (string, error)
@@ -9,5 +13,53 @@ type NotificationProvider interface {
 }
 
 type TemplateRenderer interface {
-	Render(templateName, subject string, data any, preHeader *string) (string, error)
+	Render(templateName, locale, subject string, data any, preHeader *string) (string, error)
+	// RenderText renders the explicit plain-text variant, "" when there is none
+	RenderText(templateName, locale, subject string, data any, preHeader *string) (string, error)
+	// Localize returns the translated preheader for locale, and a translated
+	// subject when subject is empty; otherwise the given values
+	Localize(templateName, locale, subject string, preHeader *string, data any) (string, *string)
+	// Version returns the stored version used for templateName in locale, 0 for the embedded copy
+	Version(templateName, locale string) int
+	// Check parses a template body without installing it
+	Check(name, body string) error
+	// Reload installs the currently published templates from source
+	Reload(ctx context.Context, source TemplateSource) error
+}
+
+// ShortMessage is a template rendered for SMS or push
+type ShortMessage struct {
+	Title string
+	Body  string
+	// Link is the deep link opened from a push notification
+	Link string
+}
+
+type ShortMessageRenderer interface {
+	// RenderShort renders the channel's variant of templateName
+	RenderShort(channel domain.NotificationType, templateName, locale string, data any) (*ShortMessage, error)
+}
+
+// TemplateSource supplies the published templates that override the embedded ones
+type TemplateSource interface {
+	FindPublished(ctx context.Context) ([]*domain.TemplateVersion, error)
+}
+
+// ComposedEmail is a fully rendered email, ready to be sent or previewed
+type ComposedEmail struct {
+	Subject string
+	HTML    string
+	Text    string
+	MIME    []byte
+	// TemplateVersion is the stored template version used, 0 for the embedded copy
+	TemplateVersion int
+}
+
+// MessageSigner signs a composed MIME message, e.g. with DKIM
+type MessageSigner interface {
+	Sign(message []byte) ([]byte, error)
+}
+
+type EmailComposer interface {
+	Compose(notification *domain.Notification) (*ComposedEmail, error)
 }
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/commitshark/notification-svc/internal/domain"
 )
@@ -10,13 +11,84 @@ import (
 type NotificationRepository interface {
 	Save(ctx context.Context, notification *domain.Notification) error
 	FindByID(ctx context.Context, id string) (*domain.Notification, error)
+	// FindPending returns pending and failed notifications whose next attempt is due
 	FindPending(ctx context.Context, limit int) ([]*domain.Notification, error)
+	// ClaimPending leases up to limit due notifications until the given time and
+	// returns them. Workers sharing the store never claim the same notification.
+	ClaimPending(ctx context.Context, limit int, until time.Time) ([]*domain.Notification, error)
 	PaginatedList(ctx context.Context, page, pageSize int, filter domain.NotificationFilter) ([]*domain.Notification, int, error)
+	FindIDs(ctx context.Context, filter domain.NotificationFilter) ([]string, error)
+	CountByStatus(ctx context.Context, filter domain.NotificationFilter) (map[domain.NotificationStatus]int, error)
 	UpdateStatus(ctx context.Context, id string, status domain.NotificationStatus, providerResponse string) error
 	IncrementRetryCount(ctx context.Context, id string) error
+	// DeleteByIDs removes the notifications among ids that still match filter,
+	// so rows that changed since they were selected stay, and returns how many
+	// were deleted. archive, when set, is given the rows as they were deleted
+	// before the deletion is committed; an error from it keeps them all.
+	DeleteByIDs(ctx context.Context, ids []string, filter domain.NotificationFilter, archive func([]*domain.Notification) error) (int, error)
+	// Compact reclaims the space freed by deleted notifications
+	Compact(ctx context.Context) error
+	// Reencrypt moves up to limit notifications stored in plaintext or under a
+	// retired key, with IDs after afterID, to the current encryption key
+	Reencrypt(ctx context.Context, afterID string, limit int) (ReencryptBatch, error)
+	Close() error
+}
+
+// ReencryptBatch reports one Reencrypt call
+type ReencryptBatch struct {
+	// LastID is the afterID of the next batch, empty once no rows are left
+	LastID string
+	Moved  int
+	// Skipped holds the errors of rows that couldn't be decrypted; they are
+	// left as they are
+	Skipped []error
+}
+
+// NotificationArchive keeps the notifications removed by retention
+type NotificationArchive interface {
+	// Create starts a new archive named name, e.g. one per retention run
+	Create(name string) (ArchiveWriter, error)
+}
+
+type ArchiveWriter interface {
+	// Append adds notifications to the archive; they are durable once it returns
+	Append(notifications []*domain.Notification) error
+	// Location identifies the archive, e.g. its file path
+	Location() string
+	Close() error
+}
+
+type CampaignRepository interface {
+	// Save inserts or updates c, failing with domain.ErrCampaignConflict when
+	// it was changed since it was loaded
+	Save(ctx context.Context, c *domain.Campaign) error
+	FindByID(ctx context.Context, id string) (*domain.Campaign, error)
+	List(ctx context.Context) ([]*domain.Campaign, error)
+	// FindActive returns the scheduled and sending campaigns
+	FindActive(ctx context.Context) ([]*domain.Campaign, error)
 	Close() error
 }
 
 type UserDataAdapter interface {
 	GetContactInfo(ctx context.Context, userID string) (*domain.UserContactInfo, error)
+	// The lookups below return the user's ID, or domain.ErrRecipientNotResolved
+	// when there is no such user
+	GetEventOrganizer(ctx context.Context, event string) (string, error)
+	GetSystemUser(ctx context.Context) (string, error)
+	GetReferrer(ctx context.Context, userID string) (string, error)
+}
+
+type TemplateRepository interface {
+	// CreateVersion stores t as the next version of t.Name
+	CreateVersion(ctx context.Context, t *domain.TemplateVersion) error
+	UpdateVersion(ctx context.Context, t *domain.TemplateVersion) error
+	DeleteVersion(ctx context.Context, name string, version int) error
+	FindVersion(ctx context.Context, name string, version int) (*domain.TemplateVersion, error)
+	ListVersions(ctx context.Context, name string) ([]*domain.TemplateVersion, error)
+	// Publish makes version the live one for name and archives the previous one
+	Publish(ctx context.Context, name string, version int) error
+	// Unpublish archives the live version so the embedded template is used again
+	Unpublish(ctx context.Context, name string) error
+	TemplateSource
+	Close() error
 }
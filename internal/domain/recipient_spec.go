@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RecipientKind says how a recipient specifier is resolved to a user
+type RecipientKind string
+
+const (
+	RecipientUser        RecipientKind = "user"         // a user ID
+	RecipientOrganizerOf RecipientKind = "organizer_of" // organizer_of:<event>
+	RecipientSystemUser  RecipientKind = "system_user"  // system_user
+	RecipientReferrerOf  RecipientKind = "referrer_of"  // referrer_of:<user_id>
+)
+
+var (
+	ErrInvalidRecipientSpec = errors.New("invalid recipient")
+	// ErrRecipientNotResolved means the user service has no user for a specifier
+	ErrRecipientNotResolved = errors.New("recipient could not be resolved")
+)
+
+// RecipientSpec names who a notification is for: a user ID, or a role
+// resolved through the user service when the notification is ingested
+type RecipientSpec struct {
+	Kind RecipientKind
+	Arg  string
+}
+
+// ParseRecipientSpec parses a user ID or a symbolic specifier such as
+// "organizer_of:<event>", "system_user" or "referrer_of:<user_id>"
+func ParseRecipientSpec(raw string) (RecipientSpec, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return RecipientSpec{}, fmt.Errorf("%w: recipient cannot be empty", ErrInvalidRecipientSpec)
+	}
+
+	if raw == string(RecipientSystemUser) {
+		return RecipientSpec{Kind: RecipientSystemUser}, nil
+	}
+
+	kind, arg, found := strings.Cut(raw, ":")
+	if !found {
+		return RecipientSpec{Kind: RecipientUser, Arg: raw}, nil
+	}
+
+	switch RecipientKind(kind) {
+	case RecipientOrganizerOf, RecipientReferrerOf:
+	default:
+		return RecipientSpec{}, fmt.Errorf("%w: unknown recipient kind %q", ErrInvalidRecipientSpec, kind)
+	}
+
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return RecipientSpec{}, fmt.Errorf("%w: %s needs a value", ErrInvalidRecipientSpec, kind)
+	}
+
+	return RecipientSpec{Kind: RecipientKind(kind), Arg: arg}, nil
+}
+
+// IsSymbolic reports whether the specifier needs resolving to a user ID
+func (s RecipientSpec) IsSymbolic() bool {
+	return s.Kind != RecipientUser
+}
+
+func (s RecipientSpec) String() string {
+	switch s.Kind {
+	case RecipientUser:
+		return s.Arg
+	case RecipientSystemUser:
+		return string(s.Kind)
+	default:
+		return string(s.Kind) + ":" + s.Arg
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Retention categories split notifications by their is_marketing flag
+const (
+	RetentionMarketing     = "marketing"
+	RetentionTransactional = "transactional"
+)
+
+var ErrInvalidRetentionPolicy = errors.New("invalid retention policy")
+
+// RetentionPolicy removes notifications in Status once they are older than
+// MaxAge. Category narrows it to marketing or transactional notifications;
+// empty matches both.
+type RetentionPolicy struct {
+	Status   NotificationStatus
+	Category string
+	MaxAge   time.Duration
+}
+
+func (p RetentionPolicy) Validate() error {
+	switch p.Status {
+	case StatusSent, StatusDelivered, StatusFailed, StatusCancelled, StatusRejected:
+	default:
+		return fmt.Errorf("%w: status %q can't be purged", ErrInvalidRetentionPolicy, p.Status)
+	}
+
+	switch p.Category {
+	case "", RetentionMarketing, RetentionTransactional:
+	default:
+		return fmt.Errorf("%w: unknown category %q", ErrInvalidRetentionPolicy, p.Category)
+	}
+
+	if p.MaxAge <= 0 {
+		return fmt.Errorf("%w: max age must be positive", ErrInvalidRetentionPolicy)
+	}
+
+	return nil
+}
+
+// Filter matches the notifications the policy removes as of now
+func (p RetentionPolicy) Filter(now time.Time) NotificationFilter {
+	status := p.Status
+	before := now.Add(-p.MaxAge)
+
+	filter := NotificationFilter{
+		Status:        &status,
+		CreatedBefore: &before,
+		Settled:       true,
+	}
+
+	if p.Category != "" {
+		isMarketing := p.Category == RetentionMarketing
+		filter.IsMarketing = &isMarketing
+	}
+
+	return filter
+}
+
+func (p RetentionPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Status   NotificationStatus `json:"status"`
+		Category string             `json:"category,omitempty"`
+		MaxAge   string             `json:"max_age"`
+	}{p.Status, p.Category, p.MaxAge.String()})
+}
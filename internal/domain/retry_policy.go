@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// RetryPolicy decides how often and how far apart a failed notification is retried
+type RetryPolicy struct {
+	// MaxAttempts bounds the failed attempts before a notification stays failed
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Jitter randomizes each delay by up to this fraction either way, so
+	// notifications that failed together don't retry together. Nil is unset;
+	// 0 turns jitter off.
+	Jitter *float64
+}
+
+var defaultJitter = 0.2
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   5 * time.Second,
+	MaxDelay:    30 * time.Minute,
+	Jitter:      &defaultJitter,
+}
+
+// WithDefaults fills the unset fields of p from def
+func (p RetryPolicy) WithDefaults(def RetryPolicy) RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = def.MaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = def.BaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = def.MaxDelay
+	}
+	if p.Jitter == nil {
+		p.Jitter = def.Jitter
+	}
+	return p
+}
+
+// Delay returns the wait before the next attempt after failures earlier failed
+// ones: BaseDelay doubled per failure and jittered, capped at MaxDelay
+func (p RetryPolicy) Delay(failures int) time.Duration {
+	delay := p.BaseDelay
+	for i := 0; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.Jitter != nil && *p.Jitter > 0 {
+		spread := *p.Jitter * (2*rand.Float64() - 1)
+		delay += time.Duration(float64(delay) * spread)
+	}
+
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// RetryPolicies picks the retry policy of a notification: its template's, else
+// its channel's, else Default
+type RetryPolicies struct {
+	Default   RetryPolicy
+	Channels  map[NotificationType]RetryPolicy
+	Templates map[string]RetryPolicy
+}
+
+func (p RetryPolicies) For(channel NotificationType, template *string) RetryPolicy {
+	def := p.Default.WithDefaults(DefaultRetryPolicy)
+
+	if template != nil {
+		if policy, ok := p.Templates[strings.ToLower(*template)]; ok {
+			return policy.WithDefaults(def)
+		}
+	}
+
+	if policy, ok := p.Channels[channel]; ok {
+		return policy.WithDefaults(def)
+	}
+
+	return def
+}
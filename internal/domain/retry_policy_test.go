@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	noJitter := 0.0
+
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		failures int
+		want     time.Duration
+	}{
+		{"first retry", RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute, Jitter: &noJitter}, 0, time.Second},
+		{"doubles", RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute, Jitter: &noJitter}, 3, 8 * time.Second},
+		{"capped", RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute, Jitter: &noJitter}, 10, time.Minute},
+		{"many failures", RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute, Jitter: &noJitter}, 1000, time.Minute},
+		{"unset jitter", RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}, 2, 4 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				if got := tt.policy.Delay(tt.failures); got != tt.want {
+					t.Fatalf("Delay(%d) = %v, want %v", tt.failures, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelayJitter(t *testing.T) {
+	jitter := 0.2
+	policy := RetryPolicy{BaseDelay: 10 * time.Second, MaxDelay: time.Hour, Jitter: &jitter}
+
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 200; i++ {
+		got := policy.Delay(1)
+		if got < 16*time.Second || got > 24*time.Second {
+			t.Fatalf("Delay(1) = %v, want 20s ± 20%%", got)
+		}
+		seen[got] = true
+	}
+	if len(seen) < 2 {
+		t.Error("jitter never changed the delay")
+	}
+
+	// Jitter never pushes a delay past the cap
+	capped := RetryPolicy{BaseDelay: time.Minute, MaxDelay: time.Minute, Jitter: &jitter}
+	for i := 0; i < 200; i++ {
+		if got := capped.Delay(5); got > time.Minute {
+			t.Fatalf("Delay(5) = %v, past the %v cap", got, time.Minute)
+		}
+	}
+}
+
+func TestRetryPoliciesFor(t *testing.T) {
+	noJitter := 0.0
+	otp := "OTP"
+	welcome := "welcome"
+
+	policies := RetryPolicies{
+		Default: RetryPolicy{MaxAttempts: 4},
+		Channels: map[NotificationType]RetryPolicy{
+			SMSNotification: {MaxAttempts: 6, BaseDelay: time.Second, Jitter: &noJitter},
+		},
+		Templates: map[string]RetryPolicy{
+			"otp": {MaxAttempts: 2, MaxDelay: 10 * time.Second},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		channel  NotificationType
+		template *string
+		want     RetryPolicy
+	}{
+		{"default", EmailNotification, nil, RetryPolicy{MaxAttempts: 4, BaseDelay: 5 * time.Second, MaxDelay: 30 * time.Minute, Jitter: &defaultJitter}},
+		{"channel", SMSNotification, &welcome, RetryPolicy{MaxAttempts: 6, BaseDelay: time.Second, MaxDelay: 30 * time.Minute, Jitter: &noJitter}},
+		{"template over channel", SMSNotification, &otp, RetryPolicy{MaxAttempts: 2, BaseDelay: 5 * time.Second, MaxDelay: 10 * time.Second, Jitter: &defaultJitter}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := policies.For(tt.channel, tt.template)
+			if got.MaxAttempts != tt.want.MaxAttempts || got.BaseDelay != tt.want.BaseDelay || got.MaxDelay != tt.want.MaxDelay {
+				t.Errorf("For = %+v, want %+v", got, tt.want)
+			}
+			if got.Jitter == nil || *got.Jitter != *tt.want.Jitter {
+				t.Errorf("jitter = %v, want %v", got.Jitter, *tt.want.Jitter)
+			}
+		})
+	}
+}
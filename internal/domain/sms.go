@@ -0,0 +1,83 @@
+package domain
+
+import "strings"
+
+const (
+	// MaxSMSSegments caps how many concatenated parts a rendered SMS may use
+	MaxSMSSegments = 3
+
+	gsmSingleLimit  = 160
+	gsmPartLimit    = 153
+	ucs2SingleLimit = 70
+	ucs2PartLimit   = 67
+)
+
+const (
+	gsmBasic    = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsmExtended = "^{}\\[~]|€\f"
+)
+
+// SMSLength returns the number of encoded characters of text and whether it
+// needs UCS-2 (any character outside the GSM 03.38 alphabet).
+func SMSLength(text string) (length int, unicode bool) {
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune(gsmBasic, r):
+			length++
+		case strings.ContainsRune(gsmExtended, r):
+			length += 2
+		default:
+			unicode = true
+		}
+	}
+
+	if unicode {
+		// UCS-2 counts UTF-16 code units
+		length = 0
+		for _, r := range text {
+			if r > 0xFFFF {
+				length += 2
+			} else {
+				length++
+			}
+		}
+	}
+
+	return length, unicode
+}
+
+// SMSSegments returns how many parts text is split into when sent
+func SMSSegments(text string) int {
+	length, unicode := SMSLength(text)
+
+	single, part := gsmSingleLimit, gsmPartLimit
+	if unicode {
+		single, part = ucs2SingleLimit, ucs2PartLimit
+	}
+
+	if length == 0 {
+		return 0
+	}
+	if length <= single {
+		return 1
+	}
+	return (length + part - 1) / part
+}
+
+// FitSMS shortens text with an ellipsis so it fits in maxSegments parts
+func FitSMS(text string, maxSegments int) string {
+	if SMSSegments(text) <= maxSegments {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		// "..." keeps GSM-7 messages from switching to UCS-2
+		candidate := strings.TrimSpace(string(runes)) + "..."
+		if SMSSegments(candidate) <= maxSegments {
+			return candidate
+		}
+	}
+	return ""
+}
@@ -1,84 +1,68 @@
 package templates
 
 import (
+	"encoding/base64"
 	"fmt"
 	"strings"
-	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
 )
 
 type AttendeeTicketPurchaseEmailData struct {
-	TicketID   string  `json:"ticket_id"`
-	QR         string  `json:"qr"`
-	EventID    string  `json:"event_id"`
-	EventTitle string  `json:"event_title"`
-	TicketType string  `json:"ticket_type"`
-	Date       string  `json:"date"`
-	Amount     string  `json:"amount"`
-	IsRSVP     bool    `json:"is_rsvp"`
-	Location   *string `json:"location"`
-	Admits     int     `json:"admits"`
+	TicketID string `json:"ticket_id" validate:"required"`
+	QR       string `json:"qr"`
+	EventID  string `json:"event_id" validate:"required"`
+	// OccurrenceID keys the calendar invite, none is attached without it
+	OccurrenceID string  `json:"occurrence_id,omitempty"`
+	EventTitle   string  `json:"event_title" validate:"required"`
+	TicketType   string  `json:"ticket_type"`
+	Date         string  `json:"date" validate:"required"`
+	Amount       string  `json:"amount"`
+	IsRSVP       bool    `json:"is_rsvp"`
+	Location     *string `json:"location"`
+	Admits       int     `json:"admits"`
+	EventTimes
 }
 
 func (tD *AttendeeTicketPurchaseEmailData) isEmailTemplateData() {}
 
-func wrapBase64(s string, lineLen int) string {
-	var buf strings.Builder
-	for len(s) > 0 {
-		chunk := lineLen
-		if chunk > len(s) {
-			chunk = len(s)
-		}
-		buf.WriteString(s[:chunk])
-		buf.WriteString("\r\n")
-		s = s[chunk:]
-	}
-	return buf.String()
-}
+// GetAttachments returns the QR code referenced as cid:qr@local in ticket-ready.html
+// and a calendar invite for the event
+func (tD *AttendeeTicketPurchaseEmailData) GetAttachments(recipient string) ([]domain.Attachment, error) {
+	var attachments []domain.Attachment
 
-func (tD *AttendeeTicketPurchaseEmailData) GetMessage(emailFrom, email, subject, html string) []byte {
-	boundary := fmt.Sprintf("mixed-%d", time.Now().UnixNano())
-	cid := "qr@local"
+	if tD.QR != "" {
+		// Strip data URL prefix if present
+		qrBase64 := strings.TrimPrefix(tD.QR, "data:image/png;base64,")
 
-	// Strip data URL prefix if present
-	qrBase64 := strings.TrimPrefix(tD.QR, "data:image/png;base64,")
+		qr, err := base64.StdEncoding.DecodeString(qrBase64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid qr code: %w", err)
+		}
 
-	// ✅ Wrap at 76 chars per RFC 2045
-	qrBase64Wrapped := wrapBase64(qrBase64, 76)
+		attachments = append(attachments, domain.Attachment{
+			Filename:    "qr.png",
+			ContentType: "image/png",
+			Disposition: domain.DispositionInline,
+			ContentID:   "qr@local",
+			Data:        qr,
+		})
+	}
 
-	message := fmt.Sprintf(
-		"From: %s\r\n"+
-			"To: %s\r\n"+
-			"Subject: %s\r\n"+
-			"MIME-Version: 1.0\r\n"+
-			"Content-Type: multipart/related; boundary=\"%s\"\r\n"+
-			"\r\n"+
-			"--%s\r\n"+
-			"Content-Type: text/html; charset=\"UTF-8\"\r\n"+
-			"\r\n"+
-			"%s\r\n"+
-			"\r\n"+
-			"--%s\r\n"+
-			"Content-Type: image/png\r\n"+
-			"Content-ID: <%s>\r\n"+
-			"Content-Disposition: inline; filename=\"qr.png\"\r\n"+ // ✅ Added
-			"Content-Transfer-Encoding: base64\r\n"+
-			"\r\n"+
-			"%s\r\n"+ // ✅ Now wrapped
-			"\r\n"+
-			"--%s--\r\n",
-		emailFrom,
-		email,
-		subject,
-		boundary,
-		boundary,
-		html,
-		boundary,
-		cid,
-		qrBase64Wrapped, // ✅ Use wrapped version
-		boundary,
-	)
+	invite := CalendarEvent{
+		UID:      calendarUID(tD.OccurrenceID),
+		Method:   CalendarMethodRequest,
+		Summary:  tD.EventTitle,
+		Attendee: recipient,
+	}
+	if tD.Location != nil {
+		invite.Location = *tD.Location
+	}
+	if invite.UID != "" && tD.resolve(&invite, tD.Date, "", "") == nil {
+		attachments = append(attachments, invite.Attachment())
+	}
 
-	return []byte(message)
+	return attachments, nil
 }
 
 func (tD *AttendeeTicketPurchaseEmailData) GetPreHeader() *string {
@@ -0,0 +1,279 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // the runtime image ships without zoneinfo
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
+
+const (
+	CalendarMethodRequest = "REQUEST"
+	CalendarMethodCancel  = "CANCEL"
+
+	// DefaultEventTimezone is used when the producer doesn't send a timezone.
+	DefaultEventTimezone = "Africa/Lagos"
+
+	calendarOrganizer = "noreply@eventor.com.ng"
+	calendarUIDDomain = "eventor.com.ng"
+)
+
+var (
+	eventDateLayouts = []string{
+		"2006-01-02",
+		"Jan 2, 2006",
+		"January 2, 2006",
+		"Mon, Jan 2, 2006",
+		"Monday, January 2, 2006",
+		"2 Jan 2006",
+		"02/01/2006",
+	}
+
+	eventTimeLayouts = []string{
+		"15:04",
+		"15:04:05",
+		"3:04 PM",
+		"3:04PM",
+		"3 PM",
+		"3PM",
+	}
+)
+
+// CalendarEvent is a single VEVENT sent as an iCalendar attachment.
+type CalendarEvent struct {
+	UID         string
+	Method      string
+	Sequence    int
+	Summary     string
+	Description string
+	Location    string
+	// Attendee is the address of the recipient the invite is for
+	Attendee string
+	Start    time.Time
+	End      *time.Time
+	// AllDay events only have a date; Start is midnight of that date in the
+	// event's timezone and End is ignored
+	AllDay bool
+}
+
+// ICS renders the event as an RFC 5545 calendar object.
+func (e CalendarEvent) ICS() []byte {
+	status := "CONFIRMED"
+	if e.Method == CalendarMethodCancel {
+		status = "CANCELLED"
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Eventor//Notification Service//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:" + e.Method,
+		"BEGIN:VEVENT",
+		"UID:" + e.UID,
+		"DTSTAMP:" + formatICSTime(time.Now()),
+		fmt.Sprintf("SEQUENCE:%d", e.Sequence),
+	}
+	switch {
+	case e.AllDay:
+		lines = append(lines,
+			"DTSTART;VALUE=DATE:"+formatICSDate(e.Start),
+			"DTEND;VALUE=DATE:"+formatICSDate(e.Start.AddDate(0, 0, 1)),
+		)
+	case e.End != nil:
+		lines = append(lines, "DTSTART:"+formatICSTime(e.Start), "DTEND:"+formatICSTime(*e.End))
+	default:
+		lines = append(lines, "DTSTART:"+formatICSTime(e.Start))
+	}
+	lines = append(lines,
+		"SUMMARY:"+escapeICSText(e.Summary),
+		"ORGANIZER;CN=Eventor:mailto:"+calendarOrganizer,
+		"STATUS:"+status,
+	)
+	if e.Attendee != "" {
+		lines = append(lines, "ATTENDEE;CUTYPE=INDIVIDUAL;ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED;RSVP=FALSE:mailto:"+e.Attendee)
+	}
+	if e.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeICSText(e.Location))
+	}
+	if e.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICSText(e.Description))
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(foldICSLine(l))
+	}
+	return []byte(b.String())
+}
+
+// Attachment wraps the calendar object so every email provider can send it.
+func (e CalendarEvent) Attachment() domain.Attachment {
+	filename := "invite.ics"
+	if e.Method == CalendarMethodCancel {
+		filename = "cancel.ics"
+	}
+
+	return domain.Attachment{
+		Filename:    filename,
+		ContentType: fmt.Sprintf("text/calendar; charset=UTF-8; method=%s", e.Method),
+		Disposition: domain.DispositionAttachment,
+		Data:        e.ICS(),
+	}
+}
+
+// calendarUID derives a stable UID so a CANCEL replaces the REQUEST sent earlier
+// for the same occurrence. Cancellations always carry the occurrence ID, so
+// invites are only sent when it is known; empty means no invite.
+func calendarUID(occurrenceID string) string {
+	if occurrenceID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s@%s", occurrenceID, calendarUIDDomain)
+}
+
+// EventTimes holds the schedule fields shared by the event templates. StartsAt and
+// EndsAt are RFC 3339 timestamps; when absent the display date/time strings are parsed
+// in Timezone.
+type EventTimes struct {
+	StartsAt string `json:"starts_at,omitempty"`
+	EndsAt   string `json:"ends_at,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+func (t EventTimes) location() (*time.Location, error) {
+	tz := t.Timezone
+	if tz == "" {
+		tz = DefaultEventTimezone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", tz, err)
+	}
+	return loc, nil
+}
+
+// resolve fills the schedule of event from the structured times or, when
+// absent, the display date and times. A date without a time of day makes it
+// an all-day event.
+func (t EventTimes) resolve(event *CalendarEvent, date, startTime, endTime string) error {
+	loc, err := t.location()
+	if err != nil {
+		return err
+	}
+
+	var start time.Time
+	hasClock := true
+	if t.StartsAt != "" {
+		start, err = time.Parse(time.RFC3339, t.StartsAt)
+	} else {
+		start, hasClock, err = parseEventDateTime(date, startTime, loc)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid event start: %w", err)
+	}
+
+	event.Start = start
+	event.AllDay = !hasClock
+
+	if t.EndsAt != "" {
+		e, err := time.Parse(time.RFC3339, t.EndsAt)
+		if err != nil {
+			return fmt.Errorf("invalid event end: %w", err)
+		}
+		event.End = &e
+	} else if endTime != "" && hasClock {
+		e, _, err := parseEventDateTime(date, endTime, loc)
+		if err == nil {
+			// Events ending past midnight
+			if e.Before(start) {
+				e = e.AddDate(0, 0, 1)
+			}
+			event.End = &e
+		}
+	}
+
+	return nil
+}
+
+// parseEventDateTime parses a display date and optional time of day and
+// reports whether a time of day was found
+func parseEventDateTime(date, clock string, loc *time.Location) (time.Time, bool, error) {
+	date = strings.TrimSpace(date)
+	clock = strings.TrimSpace(clock)
+
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t, true, nil
+	}
+
+	// The date may already carry the time, e.g. "Dec 2, 2025 7:00 PM"
+	value := strings.TrimSpace(date + " " + clock)
+
+	for _, dl := range eventDateLayouts {
+		for _, tl := range eventTimeLayouts {
+			if t, err := time.ParseInLocation(dl+" "+tl, value, loc); err == nil {
+				return t, true, nil
+			}
+		}
+		if clock == "" {
+			if t, err := time.ParseInLocation(dl, date, loc); err == nil {
+				return t, false, nil
+			}
+		}
+	}
+
+	return time.Time{}, false, fmt.Errorf("unrecognised date/time %q %q", date, clock)
+}
+
+// formatICSDate formats the date in t's own timezone, as all-day events float
+func formatICSDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func escapeICSText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// foldICSLine splits content lines longer than 75 octets (RFC 5545 §3.1)
+// without breaking UTF-8 sequences.
+func foldICSLine(line string) string {
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if strings.TrimSpace(p) != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, sep)
+}
@@ -0,0 +1,205 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// unfoldICS joins folded content lines back into one line each
+func unfoldICS(ics []byte) []string {
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(ics), "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestCalendarEventICS(t *testing.T) {
+	start := time.Date(2026, 3, 14, 18, 0, 0, 0, time.FixedZone("WAT", 3600))
+	end := start.Add(2 * time.Hour)
+
+	tests := []struct {
+		name    string
+		event   CalendarEvent
+		want    []string
+		without []string
+	}{
+		{
+			name: "request",
+			event: CalendarEvent{
+				UID: "occ-1@eventor.com.ng", Method: CalendarMethodRequest, Summary: "Jazz; live, at last",
+				Location: "Hall A", Description: "Doors open 5pm\nBring ID", Attendee: "a@example.com",
+				Start: start, End: &end,
+			},
+			want: []string{
+				"METHOD:REQUEST",
+				"UID:occ-1@eventor.com.ng",
+				"SEQUENCE:0",
+				"DTSTART:20260314T170000Z",
+				"DTEND:20260314T190000Z",
+				`SUMMARY:Jazz\; live\, at last`,
+				"STATUS:CONFIRMED",
+				"ATTENDEE;CUTYPE=INDIVIDUAL;ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED;RSVP=FALSE:mailto:a@example.com",
+				"LOCATION:Hall A",
+				`DESCRIPTION:Doors open 5pm\nBring ID`,
+			},
+		},
+		{
+			name:    "cancel",
+			event:   CalendarEvent{UID: "occ-1@eventor.com.ng", Method: CalendarMethodCancel, Sequence: 1, Summary: "Jazz", Start: start},
+			want:    []string{"METHOD:CANCEL", "SEQUENCE:1", "STATUS:CANCELLED", "DTSTART:20260314T170000Z"},
+			without: []string{"DTEND", "ATTENDEE", "LOCATION", "DESCRIPTION"},
+		},
+		{
+			name:  "all day",
+			event: CalendarEvent{UID: "occ-2@eventor.com.ng", Method: CalendarMethodRequest, Summary: "Fair", Start: start, End: &end, AllDay: true},
+			want:  []string{"DTSTART;VALUE=DATE:20260314", "DTEND;VALUE=DATE:20260315"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := unfoldICS(tt.event.ICS())
+			if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-1] != "END:VCALENDAR" {
+				t.Fatalf("calendar = %q", lines)
+			}
+
+			has := make(map[string]bool, len(lines))
+			for _, l := range lines {
+				has[l] = true
+			}
+			for _, w := range tt.want {
+				if !has[w] {
+					t.Errorf("missing %q in %q", w, lines)
+				}
+			}
+			for _, l := range lines {
+				for _, w := range tt.without {
+					if strings.HasPrefix(l, w) {
+						t.Errorf("unexpected %q", l)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarEventAttachment(t *testing.T) {
+	request := CalendarEvent{UID: "u", Method: CalendarMethodRequest, Start: time.Now()}.Attachment()
+	if request.Filename != "invite.ics" || request.ContentType != "text/calendar; charset=UTF-8; method=REQUEST" {
+		t.Errorf("request attachment = %s, %s", request.Filename, request.ContentType)
+	}
+
+	cancel := CalendarEvent{UID: "u", Method: CalendarMethodCancel, Start: time.Now()}.Attachment()
+	if cancel.Filename != "cancel.ics" || !strings.HasSuffix(cancel.ContentType, "method=CANCEL") {
+		t.Errorf("cancel attachment = %s, %s", cancel.Filename, cancel.ContentType)
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	tests := []string{
+		"SUMMARY:short",
+		"SUMMARY:" + strings.Repeat("a", 200),
+		"SUMMARY:" + strings.Repeat("é", 100),
+		"SUMMARY:" + strings.Repeat("🎷", 50),
+	}
+
+	for _, line := range tests {
+		folded := foldICSLine(line)
+		for _, l := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+			if len(l) > 75 {
+				t.Errorf("line of %d octets", len(l))
+			}
+			if !utf8.ValidString(l) {
+				t.Errorf("fold split a UTF-8 sequence: %q", l)
+			}
+		}
+		if got := strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""); got != line {
+			t.Errorf("unfolded = %q, want %q", got, line)
+		}
+	}
+}
+
+func TestEventTimesResolve(t *testing.T) {
+	lagos, err := time.LoadLocation(DefaultEventTimezone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		times      EventTimes
+		date       string
+		start, end string
+		wantStart  time.Time
+		wantEnd    *time.Time
+		allDay     bool
+		wantErr    bool
+	}{
+		{
+			name:      "structured",
+			times:     EventTimes{StartsAt: "2026-03-14T18:00:00+01:00", EndsAt: "2026-03-14T20:00:00+01:00"},
+			wantStart: time.Date(2026, 3, 14, 17, 0, 0, 0, time.UTC),
+			wantEnd:   ptr(time.Date(2026, 3, 14, 19, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "display in default timezone", date: "Mar 14, 2026", start: "6:00 PM", end: "8:00 PM",
+			wantStart: time.Date(2026, 3, 14, 18, 0, 0, 0, lagos),
+			wantEnd:   ptr(time.Date(2026, 3, 14, 20, 0, 0, 0, lagos)),
+		},
+		{
+			name: "past midnight", date: "2026-03-14", start: "22:00", end: "02:00",
+			wantStart: time.Date(2026, 3, 14, 22, 0, 0, 0, lagos),
+			wantEnd:   ptr(time.Date(2026, 3, 15, 2, 0, 0, 0, lagos)),
+		},
+		{
+			name: "given timezone", times: EventTimes{Timezone: "Europe/Paris"}, date: "14/03/2026", start: "18:00",
+			wantStart: time.Date(2026, 3, 14, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "date only", date: "Saturday, March 14, 2026",
+			wantStart: time.Date(2026, 3, 14, 0, 0, 0, 0, lagos),
+			allDay:    true,
+		},
+		{name: "unknown timezone", times: EventTimes{Timezone: "Mars/Olympus"}, date: "2026-03-14", wantErr: true},
+		{name: "unparseable date", date: "next Saturday", wantErr: true},
+		{name: "bad structured end", times: EventTimes{StartsAt: "2026-03-14T18:00:00Z", EndsAt: "tomorrow"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var event CalendarEvent
+			err := tt.times.resolve(&event, tt.date, tt.start, tt.end)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolve = %+v, want an error", event)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolve: %v", err)
+			}
+
+			if !event.Start.Equal(tt.wantStart) || event.AllDay != tt.allDay {
+				t.Errorf("start = %v (all day %v), want %v (all day %v)", event.Start, event.AllDay, tt.wantStart, tt.allDay)
+			}
+			switch {
+			case tt.wantEnd == nil && event.End != nil:
+				t.Errorf("end = %v, want none", event.End)
+			case tt.wantEnd != nil && (event.End == nil || !event.End.Equal(*tt.wantEnd)):
+				t.Errorf("end = %v, want %v", event.End, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestCalendarUID(t *testing.T) {
+	if got := calendarUID(""); got != "" {
+		t.Errorf("calendarUID(\"\") = %q, want none", got)
+	}
+	if got := calendarUID("occ-1"); got != "occ-1@eventor.com.ng" {
+		t.Errorf("calendarUID = %q", got)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -1,6 +1,10 @@
 package templates
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
 
 type ReminderVenue struct {
 	Name    string `json:"name"`
@@ -8,7 +12,7 @@ type ReminderVenue struct {
 }
 
 type ReminderEvent struct {
-	Name            string        `json:"name"`
+	Name            string        `json:"name" validate:"required"`
 	Slug            string        `json:"slug"`
 	Status          string        `json:"status"`
 	Type            string        `json:"type"`
@@ -19,42 +23,48 @@ type ReminderEvent struct {
 }
 
 type ReminderOccurrence struct {
+	ID        string  `json:"id,omitempty"`
 	Label     *string `json:"label,omitempty"`
-	StartDate string  `json:"start_date"`
+	StartDate string  `json:"start_date" validate:"required"`
 	StartTime string  `json:"start_time"`
 	EndTime   string  `json:"end_time"`
 	Duration  string  `json:"duration"`
 	Capacity  *int    `json:"capacity,omitempty"`
+	EventTimes
 }
 
 type ReminderEmailData struct {
 	Subject    string             `json:"subject"`
 	CustomNote string             `json:"custom_note"`
-	Event      ReminderEvent      `json:"event"`
-	Occurrence ReminderOccurrence `json:"occurrence"`
+	Event      ReminderEvent      `json:"event" validate:"required"`
+	Occurrence ReminderOccurrence `json:"occurrence" validate:"required"`
 }
 
 func (e *ReminderEmailData) isEmailTemplateData() {}
 
-func (e *ReminderEmailData) GetMessage(emailFrom, email, subject, html string) []byte {
-	message := fmt.Sprintf(
-		"From: %s\r\n"+
-			"To: %s\r\n"+
-			"Subject: %s\r\n"+
-			"MIME-Version: 1.0\r\n"+
-			"Content-Type: text/html; charset=\"UTF-8\"\r\n"+
-			"\r\n"+
-			"%s\r\n",
-		emailFrom,
-		email,
-		subject,
-		html,
-	)
-
-	return []byte(message)
+// GetAttachments adds a calendar invite for the occurrence when its schedule can be resolved
+func (e *ReminderEmailData) GetAttachments(recipient string) ([]domain.Attachment, error) {
+	summary := e.Event.Name
+	if e.Occurrence.Label != nil && *e.Occurrence.Label != "" {
+		summary = fmt.Sprintf("%s (%s)", e.Event.Name, *e.Occurrence.Label)
+	}
+
+	invite := CalendarEvent{
+		UID:         calendarUID(e.Occurrence.ID),
+		Method:      CalendarMethodRequest,
+		Summary:     summary,
+		Description: e.CustomNote,
+		Location:    joinNonEmpty(", ", e.Event.Venue.Name, e.Event.Venue.Address),
+		Attendee:    recipient,
+	}
+
+	if invite.UID == "" || e.Occurrence.resolve(&invite, e.Occurrence.StartDate, e.Occurrence.StartTime, e.Occurrence.EndTime) != nil {
+		return nil, nil
+	}
+
+	return []domain.Attachment{invite.Attachment()}, nil
 }
 
 func (e *ReminderEmailData) GetPreHeader() *string {
-	preHeader := "Reminder: Your event is coming up soon. Don't miss it!"
-	return &preHeader
+	return nil
 }
@@ -1,50 +1,37 @@
 package templates
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
 
 type OccurrenceCancelledEvent struct {
-	Name string `json:"name"`
-	Id   string `json:"id"`
+	Name string `json:"name" validate:"required"`
+	Id   string `json:"id" validate:"required"`
 }
 
 type OccurrenceCancelledOccurrence struct {
-	ID            string `json:"id"`
+	ID            string `json:"id" validate:"required"`
 	Label         string `json:"label"`
-	StartDate     string `json:"start_date"`
+	StartDate     string `json:"start_date" validate:"required"`
 	StartTime     string `json:"start_time"`
 	EndTime       string `json:"end_time"`
 	Duration      string `json:"duration"`
 	Capacity      int    `json:"capacity"`
 	TicketsSold   int    `json:"tickets_sold"`
 	AttendeeCount int    `json:"attendee_count"`
+	EventTimes
 }
 
 type OccurrenceCancelledData struct {
-	Event       OccurrenceCancelledEvent      `json:"event"`
-	Occurrence  OccurrenceCancelledOccurrence `json:"occurrence"`
+	Event       OccurrenceCancelledEvent      `json:"event" validate:"required"`
+	Occurrence  OccurrenceCancelledOccurrence `json:"occurrence" validate:"required"`
 	CancelledAt string                        `json:"cancelled_at"`
 }
 
 func (e *OccurrenceCancelledData) isEmailTemplateData() {}
 
-func (e *OccurrenceCancelledData) GetMessage(emailFrom, email, subject, html string) []byte {
-	message := fmt.Sprintf(
-		"From: %s\r\n"+
-			"To: %s\r\n"+
-			"Subject: %s\r\n"+
-			"MIME-Version: 1.0\r\n"+
-			"Content-Type: text/html; charset=\"UTF-8\"\r\n"+
-			"\r\n"+
-			"%s\r\n",
-		emailFrom,
-		email,
-		subject,
-		html,
-	)
-
-	return []byte(message)
-}
-
 func (e *OccurrenceCancelledData) GetPreHeader() *string {
 	preHeader := fmt.Sprintf("Event occurrence '%s' for '%s' has been cancelled. %d ticket holders have been notified.",
 		e.Occurrence.Label,
@@ -52,3 +39,40 @@ func (e *OccurrenceCancelledData) GetPreHeader() *string {
 		e.Occurrence.TicketsSold)
 	return &preHeader
 }
+
+// OccurrenceCancelledAttendeeData tells a ticket holder their session was
+// cancelled. The organizer gets OccurrenceCancelledData instead.
+type OccurrenceCancelledAttendeeData struct {
+	Event       OccurrenceCancelledEvent      `json:"event" validate:"required"`
+	Occurrence  OccurrenceCancelledOccurrence `json:"occurrence" validate:"required"`
+	CancelledAt string                        `json:"cancelled_at"`
+}
+
+func (e *OccurrenceCancelledAttendeeData) isEmailTemplateData() {}
+
+// GetAttachments sends a CANCEL for the invite previously attached to tickets and reminders
+func (e *OccurrenceCancelledAttendeeData) GetAttachments(recipient string) ([]domain.Attachment, error) {
+	summary := e.Event.Name
+	if e.Occurrence.Label != "" {
+		summary = fmt.Sprintf("%s (%s)", e.Event.Name, e.Occurrence.Label)
+	}
+
+	cancel := CalendarEvent{
+		UID:      calendarUID(e.Occurrence.ID),
+		Method:   CalendarMethodCancel,
+		Sequence: 1,
+		Summary:  summary,
+		Attendee: recipient,
+	}
+
+	if cancel.UID == "" || e.Occurrence.resolve(&cancel, e.Occurrence.StartDate, e.Occurrence.StartTime, e.Occurrence.EndTime) != nil {
+		return nil, nil
+	}
+
+	return []domain.Attachment{cancel.Attachment()}, nil
+}
+
+func (e *OccurrenceCancelledAttendeeData) GetPreHeader() *string {
+	preHeader := fmt.Sprintf("'%s' on %s has been cancelled.", e.Event.Name, e.Occurrence.StartDate)
+	return &preHeader
+}
@@ -0,0 +1,14 @@
+package templates
+
+type OrganizerEventReminderData struct {
+	TimeLeft   string `json:"time_left" validate:"required"` // e.g. "24 hours"
+	EventTitle string `json:"event_title" validate:"required"`
+	EventDate  string `json:"event_date" validate:"required"`
+	EventID    string `json:"event_id" validate:"required"`
+}
+
+func (o *OrganizerEventReminderData) isEmailTemplateData() {}
+
+func (o *OrganizerEventReminderData) GetPreHeader() *string {
+	return nil
+}
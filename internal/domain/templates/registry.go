@@ -0,0 +1,133 @@
+package templates
+
+import (
+	"sort"
+)
+
+// Definition declares a template: its name (matching emails/<name>.html), the Go
+// struct its data must decode into, and the mail metadata sent with it.
+type Definition struct {
+	Name string
+	// Schema returns a new, empty data value for the template
+	Schema func() EmailTemplateData
+	// PreHeader is used when the data doesn't compute its own preheader
+	PreHeader string
+	Headers   map[string]string
+}
+
+var bulkHeaders = map[string]string{
+	"Precedence":            "bulk",
+	"X-Mailer":              "Eventor Newsletter",
+	"List-Unsubscribe":      "<mailto:unsubscribe@eventor.com.ng?subject=unsubscribe>",
+	"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+}
+
+var registry = newRegistry(
+	Definition{
+		Name:   "ticket-ready",
+		Schema: func() EmailTemplateData { return &AttendeeTicketPurchaseEmailData{} },
+	},
+	Definition{
+		Name:   "ticket-sold",
+		Schema: func() EmailTemplateData { return &TicketSoldData{} },
+	},
+	Definition{
+		Name:   "otp",
+		Schema: func() EmailTemplateData { return &OtpData{} },
+	},
+	Definition{
+		Name:   "welcome",
+		Schema: func() EmailTemplateData { return &WelcomeData{} },
+	},
+	Definition{
+		Name:      "event-published",
+		Schema:    func() EmailTemplateData { return &EventPublishedData{} },
+		PreHeader: "Your event is now live and ready for attendees.",
+	},
+	Definition{
+		Name:   "withdrawal-complete",
+		Schema: func() EmailTemplateData { return &WithdrawalCompleteData{} },
+	},
+	Definition{
+		Name:   "guest-invite",
+		Schema: func() EmailTemplateData { return &GuestInviteData{} },
+	},
+	Definition{
+		Name:   "withdrawal-initiated-admin",
+		Schema: func() EmailTemplateData { return &WithdrawalInitiatedAdminData{} },
+	},
+	Definition{
+		Name:   "withdrawal-initiated",
+		Schema: func() EmailTemplateData { return &WithdrawalInitiatedData{} },
+	},
+	Definition{
+		Name:   "withdrawal-failed",
+		Schema: func() EmailTemplateData { return &WithdrawalFailedData{} },
+	},
+	Definition{
+		Name:   "new-signup",
+		Schema: func() EmailTemplateData { return &AdminNewSignupData{} },
+	},
+	Definition{
+		Name:    "shell",
+		Schema:  func() EmailTemplateData { return &ShellData{} },
+		Headers: bulkHeaders,
+	},
+	Definition{
+		Name:      "event-reminder",
+		Schema:    func() EmailTemplateData { return &ReminderEmailData{} },
+		PreHeader: "Reminder: Your event is coming up soon. Don't miss it!",
+	},
+	Definition{
+		Name:   "occurrence-cancelled",
+		Schema: func() EmailTemplateData { return &OccurrenceCancelledData{} },
+	},
+	Definition{
+		Name:   "occurrence-cancelled-attendee",
+		Schema: func() EmailTemplateData { return &OccurrenceCancelledAttendeeData{} },
+	},
+	Definition{
+		Name:      "organizer-event-reminder",
+		Schema:    func() EmailTemplateData { return &OrganizerEventReminderData{} },
+		PreHeader: "A quick reminder so you're fully prepared.",
+	},
+)
+
+func newRegistry(defs ...Definition) map[string]Definition {
+	r := make(map[string]Definition, len(defs))
+	for _, d := range defs {
+		if _, exists := r[d.Name]; exists {
+			panic("duplicate template definition: " + d.Name)
+		}
+		r[d.Name] = d
+	}
+	return r
+}
+
+// Lookup returns the definition of a registered template
+func Lookup(name string) (Definition, bool) {
+	d, ok := registry[name]
+	return d, ok
+}
+
+// Definitions returns all registered templates sorted by name
+func Definitions() []Definition {
+	defs := make([]Definition, 0, len(registry))
+	for _, d := range registry {
+		defs = append(defs, d)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// PreHeaderFor returns the data's own preheader or the template's declared one
+func (d Definition) PreHeaderFor(data EmailTemplateData) *string {
+	if p := data.GetPreHeader(); p != nil {
+		return p
+	}
+	if d.PreHeader != "" {
+		p := d.PreHeader
+		return &p
+	}
+	return nil
+}
@@ -1,138 +1,34 @@
 package templates
 
 import (
-	"encoding/json"
 	"fmt"
+
+	"github.com/commitshark/notification-svc/internal/domain"
 )
 
+// EmailTemplateData is the data a template renders with. Static metadata such as
+// mail headers is declared on the template's Definition in the registry.
 type EmailTemplateData interface {
 	isEmailTemplateData()
-	GetMessage(emailFrom, email, subject, html string) []byte
 	GetPreHeader() *string
 }
 
+// AttachmentsProvider is implemented by templates that carry their own files,
+// e.g. images referenced from the HTML via cid:<ContentID>. recipient is the
+// address the email goes to, calendar invites name it as attendee.
+type AttachmentsProvider interface {
+	GetAttachments(recipient string) ([]domain.Attachment, error)
+}
+
+// ParseTemplateData decodes and validates data for a registered template
 func ParseTemplateData(templateName string, data map[string]interface{}, out *EmailTemplateData) error {
-	fmt.Printf("[ParseTemplateData] templateName: %s, data: %v", templateName, data)
+	fmt.Printf("[ParseTemplateData] templateName: %s\n", templateName)
 
-	raw, err := json.Marshal(data)
+	result, err := decodeTemplateData(templateName, data)
 	if err != nil {
-		return fmt.Errorf("failed to marshal map: %w", err)
+		return err
 	}
 
-	switch templateName {
-	case "ticket-ready":
-		var result AttendeeTicketPurchaseEmailData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal ticket email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "ticket-sold":
-		var result TicketSoldData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal ticket sold email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "otp":
-		var result OtpData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal otp email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "welcome":
-		var result WelcomeData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal welcome email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "event-published":
-		var result EventPublishedData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal event published email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "withdrawal-complete":
-		var result WithdrawalCompleteData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal withdrawal complete email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "guest-invite":
-		var result GuestInviteData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal withdrawal complete email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "withdrawal-initiated-admin":
-		var result WithdrawalInitiatedAdminData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal withdrawal initiated admin email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "withdrawal-initiated":
-		var result WithdrawalInitiatedData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal withdrawal initiated email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "withdrawal-failed":
-		var result WithdrawalFailedData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal withdrawal failed email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "new-signup":
-		var result AdminNewSignupData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal withdrawal failed email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "shell":
-		var result ShellData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal shell email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "event-reminder":
-		var result ReminderEmailData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal reminder email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	case "occurrence-cancelled":
-		var result OccurrenceCancelledData
-		if err := json.Unmarshal(raw, &result); err != nil {
-			return fmt.Errorf("failed to unmarshal occurrence cancelled email data: %w", err)
-		}
-		*out = &result
-		return nil
-
-	default:
-		return fmt.Errorf("unknown template name: %s", templateName)
-	}
+	*out = result
+	return nil
 }
@@ -0,0 +1,181 @@
+package templates
+
+import (
+	"encoding/json"
+	"html/template"
+)
+
+// 1x1 transparent PNG standing in for a ticket QR code
+const sampleQR = "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII="
+
+var (
+	sampleLocation = "Eko Convention Centre, Victoria Island, Lagos"
+	sampleLabel    = "Day 1"
+	sampleCapacity = 500
+)
+
+// Sample data used by the admin preview when the caller doesn't supply any
+var samples = map[string]EmailTemplateData{
+	"ticket-ready": &AttendeeTicketPurchaseEmailData{
+		TicketID:     "TKT-2F9A-77XC",
+		QR:           sampleQR,
+		EventID:      "8d3c1f5e-6a2b-4b8e-9c1d-2f4e5a6b7c8d",
+		OccurrenceID: "3a1b2c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+		EventTitle:   "Lagos Tech Fest 2025",
+		TicketType:   "VIP",
+		Date:         "Dec 12, 2025 10:00 AM",
+		Amount:       "₦25,000.00",
+		Location:     &sampleLocation,
+		Admits:       1,
+	},
+	"ticket-sold": &TicketSoldData{
+		EventTitle: "Lagos Tech Fest 2025",
+		TicketType: "VIP",
+		EventID:    "8d3c1f5e-6a2b-4b8e-9c1d-2f4e5a6b7c8d",
+	},
+	"otp": &OtpData{
+		OtpCode:  "482913",
+		ValidFor: 10,
+	},
+	"welcome": &WelcomeData{
+		Name: "Ada",
+	},
+	"event-published": &EventPublishedData{
+		EventTitle: "Lagos Tech Fest 2025",
+		EventID:    "8d3c1f5e-6a2b-4b8e-9c1d-2f4e5a6b7c8d",
+		EventURL:   "https://eventor.com.ng/e/lagos-tech-fest-2025",
+	},
+	"withdrawal-complete": &WithdrawalCompleteData{
+		Amount:      "50000.00",
+		ReferenceID: "WDL-231222-XF9",
+		Destination: "Bank Account •••• 4421",
+		Date:        "Dec 2, 2025",
+	},
+	"guest-invite": &GuestInviteData{
+		Subject:    "You're on the guest list!",
+		CustomNote: "We'd love to have you with us.",
+		Event: GuestInviteEvent{
+			Name:      "Lagos Tech Fest 2025",
+			ExpiresAt: "Dec 10, 2025",
+		},
+		InvitationRole: "Speaker",
+		Link:           "https://eventor.com.ng/invites/abc123",
+		Admits:         2,
+	},
+	"withdrawal-initiated-admin": &WithdrawalInitiatedAdminData{
+		Amount:      "120000.00",
+		ReferenceID: "WDL-9383-ABX",
+		Destination: "Bank Account •••• 2210",
+		Date:        "Dec 2, 2025",
+		Name:        "Ada Obi",
+		Email:       "ada@example.com",
+	},
+	"withdrawal-initiated": &WithdrawalInitiatedData{
+		Amount:      "120000.00",
+		ReferenceID: "WDL-9383-ABX",
+		Destination: "Bank Account •••• 2210",
+		Date:        "Dec 2, 2025",
+		Mode:        "manual",
+	},
+	"withdrawal-failed": &WithdrawalFailedData{
+		Amount:      "120000.00",
+		ReferenceID: "WDL-9383-ABX",
+		Destination: "Bank Account •••• 2210",
+		Date:        "Dec 2, 2025",
+		Reason:      "The destination account could not be verified.",
+	},
+	"new-signup": &AdminNewSignupData{
+		Name:      "Ada Obi",
+		Email:     "ada@example.com",
+		UserID:    "0f8fad5b-d9cb-469f-a165-70867728950e",
+		CreatedAt: "Dec 2, 2025 9:41 AM",
+	},
+	"shell": &ShellData{
+		Body: template.HTML(`<tr><td style="padding:28px;"><h1>This month on Eventor</h1><p>Discover the hottest events near you.</p></td></tr>`),
+	},
+	"event-reminder": &ReminderEmailData{
+		Subject:    "Lagos Tech Fest starts tomorrow",
+		CustomNote: "Doors open at 9:30 AM. Bring a valid ID.",
+		Event: ReminderEvent{
+			Name:            "Lagos Tech Fest 2025",
+			Slug:            "lagos-tech-fest-2025",
+			Status:          "Published",
+			Type:            "Conference",
+			AccessType:      "Public",
+			RequiresPayment: true,
+			MinTicketPrice:  "₦5,000",
+			Venue: ReminderVenue{
+				Name:    "Eko Convention Centre",
+				Address: "Victoria Island, Lagos",
+			},
+		},
+		Occurrence: ReminderOccurrence{
+			ID:        "3a1b2c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+			Label:     &sampleLabel,
+			StartDate: "Dec 12, 2025",
+			StartTime: "10:00 AM",
+			EndTime:   "6:00 PM",
+			Duration:  "8 hours",
+			Capacity:  &sampleCapacity,
+		},
+	},
+	"occurrence-cancelled": &OccurrenceCancelledData{
+		Event: OccurrenceCancelledEvent{
+			Name: "Lagos Tech Fest 2025",
+			Id:   "8d3c1f5e-6a2b-4b8e-9c1d-2f4e5a6b7c8d",
+		},
+		Occurrence: OccurrenceCancelledOccurrence{
+			ID:            "3a1b2c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+			Label:         "Day 1",
+			StartDate:     "Dec 12, 2025",
+			StartTime:     "10:00 AM",
+			EndTime:       "6:00 PM",
+			Duration:      "8 hours",
+			Capacity:      500,
+			TicketsSold:   312,
+			AttendeeCount: 298,
+		},
+		CancelledAt: "Dec 5, 2025 2:15 PM",
+	},
+	"occurrence-cancelled-attendee": &OccurrenceCancelledAttendeeData{
+		Event: OccurrenceCancelledEvent{
+			Name: "Lagos Tech Fest 2025",
+			Id:   "8d3c1f5e-6a2b-4b8e-9c1d-2f4e5a6b7c8d",
+		},
+		Occurrence: OccurrenceCancelledOccurrence{
+			ID:        "3a1b2c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+			Label:     "Day 1",
+			StartDate: "Dec 12, 2025",
+			StartTime: "10:00 AM",
+			EndTime:   "6:00 PM",
+		},
+		CancelledAt: "Dec 5, 2025 2:15 PM",
+	},
+	"organizer-event-reminder": &OrganizerEventReminderData{
+		TimeLeft:   "24 hours",
+		EventTitle: "Lagos Tech Fest 2025",
+		EventDate:  "Friday, December 12, 2025 at 10:00 AM",
+		EventID:    "8d3c1f5e-6a2b-4b8e-9c1d-2f4e5a6b7c8d",
+	},
+}
+
+// SampleData returns the built-in sample data of a template as a generic map,
+// in the same shape producers send it.
+func SampleData(templateName string) (map[string]interface{}, bool) {
+	sample, ok := samples[templateName]
+	if !ok {
+		return nil, false
+	}
+
+	raw, err := json.Marshal(sample)
+	if err != nil {
+		return nil, false
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
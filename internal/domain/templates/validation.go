@@ -0,0 +1,143 @@
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldError describes a single invalid field in template data
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every problem found in the data of a template
+type ValidationError struct {
+	Template string       `json:"template"`
+	Fields   []FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, fmt.Sprintf("%s: %s", f.Field, f.Message))
+	}
+	return fmt.Sprintf("invalid data for template %s: %s", e.Template, strings.Join(parts, "; "))
+}
+
+// ValidateTemplateData checks data against the schema of the named template
+func ValidateTemplateData(templateName string, data map[string]interface{}) error {
+	_, err := decodeTemplateData(templateName, data)
+	return err
+}
+
+func decodeTemplateData(templateName string, data map[string]interface{}) (EmailTemplateData, error) {
+	def, ok := Lookup(templateName)
+	if !ok {
+		return nil, &ValidationError{
+			Template: templateName,
+			Fields:   []FieldError{{Field: "template", Message: "unknown template"}},
+		}
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal map: %w", err)
+	}
+
+	result := def.Schema()
+	if err := json.NewDecoder(bytes.NewReader(raw)).Decode(result); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, &ValidationError{
+				Template: templateName,
+				Fields: []FieldError{{
+					Field:   typeErr.Field,
+					Message: fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value),
+				}},
+			}
+		}
+		return nil, fmt.Errorf("failed to unmarshal %s email data: %w", templateName, err)
+	}
+
+	if fields := validateRequired(reflect.ValueOf(result), ""); len(fields) > 0 {
+		return nil, &ValidationError{Template: templateName, Fields: fields}
+	}
+
+	return result, nil
+}
+
+// validateRequired walks a struct and reports fields tagged `validate:"required"`
+// that hold their zero value. Field paths use the json names, e.g. "event.name".
+func validateRequired(v reflect.Value, prefix string) []FieldError {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs []FieldError
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		value := v.Field(i)
+
+		// Embedded structs share the parent's json namespace
+		if field.Anonymous {
+			errs = append(errs, validateRequired(value, prefix)...)
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		if field.Tag.Get("validate") == "required" && isBlank(value) {
+			errs = append(errs, FieldError{Field: path, Message: "is required"})
+			continue
+		}
+
+		errs = append(errs, validateRequired(value, path)...)
+	}
+
+	return errs
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func isBlank(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Struct:
+		// Reported field by field by the recursion
+		return false
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil() || (v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface && v.Len() == 0)
+	default:
+		return v.IsZero()
+	}
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "errors"
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrUserDeleted  = errors.New("user deleted")
+	ErrUserBlocked  = errors.New("user blocked")
+	// ErrUserServiceUnavailable means the user service couldn't answer; the
+	// same lookup may succeed later
+	ErrUserServiceUnavailable = errors.New("user service unavailable")
+)
+
+// IsUndeliverableUser reports whether err means the user can never be
+// notified, so retrying is pointless
+func IsUndeliverableUser(err error) bool {
+	return errors.Is(err, ErrUserNotFound) ||
+		errors.Is(err, ErrUserDeleted) ||
+		errors.Is(err, ErrUserBlocked)
+}
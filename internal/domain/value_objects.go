@@ -2,12 +2,17 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 )
 
 type UserContactInfo struct {
 	Email    string
 	Phone    *string
 	DeviceID *string
+	// Locale is the user's preferred locale, empty when their profile has none
+	Locale string
 }
 
 type Recipient struct {
@@ -15,6 +20,8 @@ type Recipient struct {
 	Email    *string `json:"email,omitempty"`
 	Phone    *string `json:"phone,omitempty"`
 	DeviceID *string `json:"device_id,omitempty"`
+	// Spec is the symbolic specifier ID was resolved from, e.g. "system_user"
+	Spec *string `json:"spec,omitempty"`
 }
 
 func NewRecipient(id string, email, phone, deviceId *string) (*Recipient, error) {
@@ -29,16 +36,154 @@ func NewRecipient(id string, email, phone, deviceId *string) (*Recipient, error)
 	}, nil
 }
 
+// Normalize canonicalizes the email and phone number. An invalid address only
+// fails when channel needs it; otherwise it is dropped.
+func (r *Recipient) Normalize(channel NotificationType, defaultCountry string) error {
+	if r.Email != nil && strings.TrimSpace(*r.Email) != "" {
+		email, err := NormalizeEmail(*r.Email)
+		if err != nil {
+			if channel == EmailNotification {
+				return err
+			}
+			r.Email = nil
+		} else {
+			r.Email = &email
+		}
+	}
+
+	if r.Phone != nil && strings.TrimSpace(*r.Phone) != "" {
+		phone, err := NormalizePhone(*r.Phone, defaultCountry)
+		if err != nil {
+			if channel == SMSNotification {
+				return err
+			}
+			r.Phone = nil
+		} else {
+			r.Phone = &phone
+		}
+	}
+
+	return r.requireAddress(channel)
+}
+
+func (r *Recipient) requireAddress(channel NotificationType) error {
+	switch channel {
+	case EmailNotification:
+		if r.Email == nil || *r.Email == "" {
+			return fmt.Errorf("%w: no email address", ErrInvalidAddress)
+		}
+	case SMSNotification:
+		if r.Phone == nil || *r.Phone == "" {
+			return fmt.Errorf("%w: no phone number", ErrInvalidAddress)
+		}
+	case PushNotification:
+		if r.DeviceID == nil || *r.DeviceID == "" {
+			return fmt.Errorf("%w: no device id", ErrInvalidAddress)
+		}
+	}
+	return nil
+}
+
+type AttachmentDisposition string
+
+const (
+	DispositionAttachment AttachmentDisposition = "attachment"
+	DispositionInline     AttachmentDisposition = "inline"
+)
+
+const (
+	MaxAttachments          = 10
+	MaxAttachmentSize       = 10 << 20 // 10MB per file
+	MaxAttachmentsTotalSize = 20 << 20 // 20MB per notification
+)
+
+// Attachment is a file sent along with the notification. The content is either
+// carried inline in Data or fetched from URL when the notification is sent.
+type Attachment struct {
+	Filename    string                `json:"filename"`
+	ContentType string                `json:"content_type"`
+	Disposition AttachmentDisposition `json:"disposition,omitempty"`
+	ContentID   string                `json:"content_id,omitempty"`
+	Data        []byte                `json:"data,omitempty"`
+	URL         string                `json:"url,omitempty"`
+}
+
+func (a *Attachment) Validate() error {
+	if a.Filename == "" {
+		return errors.New("attachment filename cannot be empty")
+	}
+
+	if a.ContentType == "" {
+		return fmt.Errorf("attachment %s: content type cannot be empty", a.Filename)
+	}
+
+	switch a.Disposition {
+	case "", DispositionAttachment:
+	case DispositionInline:
+		if a.ContentID == "" {
+			return fmt.Errorf("attachment %s: inline attachments require a content id", a.Filename)
+		}
+	default:
+		return fmt.Errorf("attachment %s: invalid disposition %q", a.Filename, a.Disposition)
+	}
+
+	if len(a.Data) == 0 && a.URL == "" {
+		return fmt.Errorf("attachment %s: data or url is required", a.Filename)
+	}
+
+	if len(a.Data) > 0 && a.URL != "" {
+		return fmt.Errorf("attachment %s: only one of data or url can be set", a.Filename)
+	}
+
+	if a.URL != "" {
+		if u, err := url.Parse(a.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("attachment %s: url must be an absolute https url", a.Filename)
+		}
+	}
+
+	if len(a.Data) > MaxAttachmentSize {
+		return fmt.Errorf("attachment %s exceeds %d bytes", a.Filename, MaxAttachmentSize)
+	}
+
+	return nil
+}
+
+func (a *Attachment) IsInline() bool {
+	return a.Disposition == DispositionInline
+}
+
+func ValidateAttachments(attachments []Attachment) error {
+	if len(attachments) > MaxAttachments {
+		return fmt.Errorf("too many attachments: %d, max %d", len(attachments), MaxAttachments)
+	}
+
+	total := 0
+	for i := range attachments {
+		if err := attachments[i].Validate(); err != nil {
+			return err
+		}
+		total += len(attachments[i].Data)
+	}
+
+	if total > MaxAttachmentsTotalSize {
+		return fmt.Errorf("attachments exceed %d bytes in total", MaxAttachmentsTotalSize)
+	}
+
+	return nil
+}
+
 type Content struct {
-	Title    string                  `json:"title"`
-	Body     *string                 `json:"body,omitempty"`
-	Data     *map[string]interface{} `json:"data,omitempty"`
-	HTML     *string                 `json:"html,omitempty"`
-	Template *string                 `json:"template,omitempty"`
+	Title       string                  `json:"title"`
+	Body        *string                 `json:"body,omitempty"`
+	Data        *map[string]interface{} `json:"data,omitempty"`
+	HTML        *string                 `json:"html,omitempty"`
+	Template    *string                 `json:"template,omitempty"`
+	Attachments []Attachment            `json:"attachments,omitempty"`
+	Locale      string                  `json:"locale,omitempty"` // BCP 47 tag, empty means the default locale
 }
 
-func NewContent(title string, body *string, data *map[string]interface{}, html, template *string) (*Content, error) {
-	if title == "" {
+func NewContent(title string, body *string, data *map[string]interface{}, html, template *string, attachments []Attachment, locale string) (*Content, error) {
+	if title == "" && (template == nil || *template == "") {
 		return nil, errors.New("title and body cannot be empty")
 	}
 
@@ -46,11 +191,22 @@ func NewContent(title string, body *string, data *map[string]interface{}, html,
 		return nil, errors.New("data and body cannot be empty")
 	}
 
+	if err := ValidateAttachments(attachments); err != nil {
+		return nil, err
+	}
+
 	return &Content{
-		Title:    title,
-		Body:     body,
-		Data:     data,
-		HTML:     html,
-		Template: template,
+		Title:       title,
+		Body:        body,
+		Data:        data,
+		HTML:        html,
+		Template:    template,
+		Attachments: attachments,
+		Locale:      NormalizeLocale(locale),
 	}, nil
 }
+
+// NormalizeLocale lowercases a locale tag and uses "-" as separator, e.g. "fr_CA" -> "fr-ca"
+func NormalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
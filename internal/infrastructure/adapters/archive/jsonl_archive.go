@@ -0,0 +1,108 @@
+package archive
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/encryption"
+)
+
+// archiveColumn binds sealed archive lines to the archive, so they can't be
+// passed off as a row's column
+const archiveColumn = "archive"
+
+// JSONLArchive writes archives as gzip-compressed JSON lines files in a
+// directory. With a keyring each notification is sealed as a whole; a nil
+// keyring writes them in plaintext.
+type JSONLArchive struct {
+	dir     string
+	keyring *encryption.Keyring
+}
+
+func NewJSONLArchive(dir string, keyring *encryption.Keyring) (*JSONLArchive, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	return &JSONLArchive{dir: dir, keyring: keyring}, nil
+}
+
+func (a *JSONLArchive) Create(name string) (ports.ArchiveWriter, error) {
+	path := filepath.Join(a.dir, name+".jsonl.gz")
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	return &jsonlWriter{file: file, keyring: a.keyring}, nil
+}
+
+// sealedLine is an archived notification encrypted with the keyring. Only its
+// ID and key are readable; OpenValue(ID, KeyID, "archive", Sealed) returns the
+// notification's JSON.
+type sealedLine struct {
+	ID     string `json:"id"`
+	KeyID  string `json:"key_id"`
+	Sealed string `json:"sealed"`
+}
+
+// jsonlWriter compresses each batch as its own gzip member. Concatenated
+// members are still one valid gzip file, and a crash can't leave a batch
+// that was reported as written without its trailer.
+type jsonlWriter struct {
+	file    *os.File
+	keyring *encryption.Keyring
+}
+
+func (w *jsonlWriter) Append(notifications []*domain.Notification) error {
+	gz := gzip.NewWriter(w.file)
+	enc := json.NewEncoder(gz)
+
+	for _, n := range notifications {
+		line, err := w.line(n)
+		if err != nil {
+			return fmt.Errorf("failed to archive notification %s: %w", n.ID, err)
+		}
+		if err := enc.Encode(line); err != nil {
+			return fmt.Errorf("failed to archive notification %s: %w", n.ID, err)
+		}
+	}
+
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to compress archive batch: %w", err)
+	}
+
+	return w.file.Sync()
+}
+
+func (w *jsonlWriter) line(n *domain.Notification) (any, error) {
+	if w.keyring == nil {
+		return n, nil
+	}
+
+	raw, err := json.Marshal(n)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := w.keyring.SealValue(n.ID, archiveColumn, string(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	return sealedLine{ID: n.ID, KeyID: w.keyring.CurrentKeyID(), Sealed: sealed}, nil
+}
+
+func (w *jsonlWriter) Location() string {
+	return w.file.Name()
+}
+
+func (w *jsonlWriter) Close() error {
+	return w.file.Close()
+}
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+)
+
+const DefaultMaxEntries = 10000
+
+// CachedUserDataAdapter caches contact info from another UserDataAdapter.
+// Entries expire after a TTL, the least recently used are evicted past the
+// size bound, and concurrent lookups of the same user share one call.
+// Role lookups are passed through uncached. A TTL of zero or less caches
+// nothing, though concurrent lookups are still shared.
+type CachedUserDataAdapter struct {
+	next       ports.UserDataAdapter
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	lru      *list.List // front is most recently used
+	inflight map[string]*lookup
+	// generation counts invalidations during a lookup, so a lookup that
+	// started before one doesn't cache the stale answer
+	generation map[string]uint64
+}
+
+type entry struct {
+	userID    string
+	info      domain.UserContactInfo
+	expiresAt time.Time
+}
+
+type lookup struct {
+	done chan struct{}
+	info *domain.UserContactInfo
+	err  error
+}
+
+func NewCachedUserDataAdapter(next ports.UserDataAdapter, ttl time.Duration, maxEntries int) *CachedUserDataAdapter {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+
+	return &CachedUserDataAdapter{
+		next:       next,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		inflight:   make(map[string]*lookup),
+		generation: make(map[string]uint64),
+	}
+}
+
+func (c *CachedUserDataAdapter) GetContactInfo(ctx context.Context, userID string) (*domain.UserContactInfo, error) {
+	c.mu.Lock()
+	if info, ok := c.get(userID, c.now()); ok {
+		c.mu.Unlock()
+		return info, nil
+	}
+
+	if l, ok := c.inflight[userID]; ok {
+		c.mu.Unlock()
+		return l.wait(ctx)
+	}
+
+	l := &lookup{done: make(chan struct{})}
+	c.inflight[userID] = l
+	generation := c.generation[userID]
+	c.mu.Unlock()
+
+	// Detached from ctx so one caller giving up doesn't fail the others waiting
+	l.info, l.err = c.next.GetContactInfo(context.WithoutCancel(ctx), userID)
+
+	c.mu.Lock()
+	stale := c.generation[userID] != generation
+	delete(c.inflight, userID)
+	delete(c.generation, userID)
+	if l.err == nil && !stale {
+		c.put(userID, *l.info, c.now())
+	}
+	c.mu.Unlock()
+	close(l.done)
+
+	return copyInfo(l.info), l.err
+}
+
+func (c *CachedUserDataAdapter) GetEventOrganizer(ctx context.Context, event string) (string, error) {
+	return c.next.GetEventOrganizer(ctx, event)
+}
+
+func (c *CachedUserDataAdapter) GetSystemUser(ctx context.Context) (string, error) {
+	return c.next.GetSystemUser(ctx)
+}
+
+func (c *CachedUserDataAdapter) GetReferrer(ctx context.Context, userID string) (string, error) {
+	return c.next.GetReferrer(ctx, userID)
+}
+
+// Invalidate drops the cached contact info of userID
+func (c *CachedUserDataAdapter) Invalidate(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[userID]; ok {
+		c.remove(el)
+	}
+	if _, ok := c.inflight[userID]; ok {
+		c.generation[userID]++
+	}
+}
+
+// Len returns the number of cached users, including expired ones not yet evicted
+func (c *CachedUserDataAdapter) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *CachedUserDataAdapter) get(userID string, now time.Time) (*domain.UserContactInfo, bool) {
+	el, ok := c.entries[userID]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*entry)
+	if now.After(e.expiresAt) {
+		c.remove(el)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(el)
+	return copyInfo(&e.info), true
+}
+
+func (c *CachedUserDataAdapter) put(userID string, info domain.UserContactInfo, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	if el, ok := c.entries[userID]; ok {
+		c.remove(el)
+	}
+
+	c.entries[userID] = c.lru.PushFront(&entry{userID: userID, info: info, expiresAt: now.Add(c.ttl)})
+
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *CachedUserDataAdapter) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*entry).userID)
+}
+
+func (l *lookup) wait(ctx context.Context) (*domain.UserContactInfo, error) {
+	select {
+	case <-l.done:
+		return copyInfo(l.info), l.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// copyInfo keeps callers from changing the cached value through its pointers
+func copyInfo(info *domain.UserContactInfo) *domain.UserContactInfo {
+	if info == nil {
+		return nil
+	}
+
+	out := *info
+	if info.Phone != nil {
+		phone := *info.Phone
+		out.Phone = &phone
+	}
+	if info.DeviceID != nil {
+		device := *info.DeviceID
+		out.DeviceID = &device
+	}
+	return &out
+}
@@ -0,0 +1,273 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
+
+// fakeUsers counts lookups; while block is set, lookups wait for it to close
+type fakeUsers struct {
+	mu      sync.Mutex
+	calls   map[string]int
+	emails  map[string]string
+	err     error
+	block   chan struct{}
+	started chan string
+}
+
+func newFakeUsers() *fakeUsers {
+	return &fakeUsers{calls: map[string]int{}, emails: map[string]string{}}
+}
+
+func (f *fakeUsers) GetContactInfo(ctx context.Context, userID string) (*domain.UserContactInfo, error) {
+	// The answer is read before blocking, like a lookup that raced a change
+	f.mu.Lock()
+	f.calls[userID]++
+	block, started, err := f.block, f.started, f.err
+	email := f.emails[userID]
+	f.mu.Unlock()
+
+	if started != nil {
+		started <- userID
+	}
+	if block != nil {
+		<-block
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if email == "" {
+		email = userID + "@example.com"
+	}
+	phone := "+2348031234567"
+	return &domain.UserContactInfo{Email: email, Phone: &phone}, nil
+}
+
+func (f *fakeUsers) GetEventOrganizer(ctx context.Context, event string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeUsers) GetSystemUser(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+func (f *fakeUsers) GetReferrer(ctx context.Context, userID string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeUsers) count(userID string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[userID]
+}
+
+func (f *fakeUsers) setEmail(userID, email string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.emails[userID] = email
+}
+
+func getContact(t *testing.T, c *CachedUserDataAdapter, userID string) *domain.UserContactInfo {
+	t.Helper()
+	info, err := c.GetContactInfo(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("GetContactInfo(%s): %v", userID, err)
+	}
+	return info
+}
+
+func TestCachedUserDataAdapterTTL(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		after     time.Duration
+		wantCalls int
+	}{
+		{"fresh", time.Minute, 30 * time.Second, 1},
+		{"at expiry", time.Minute, time.Minute, 1},
+		{"expired", time.Minute, time.Minute + time.Second, 2},
+		{"zero ttl caches nothing", 0, 0, 2},
+		{"negative ttl caches nothing", -time.Minute, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newFakeUsers()
+			c := NewCachedUserDataAdapter(users, tt.ttl, 10)
+			clock := now
+			c.now = func() time.Time { return clock }
+
+			getContact(t, c, "u1")
+			clock = clock.Add(tt.after)
+			getContact(t, c, "u1")
+
+			if got := users.count("u1"); got != tt.wantCalls {
+				t.Errorf("upstream calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCachedUserDataAdapterEvictsLeastRecentlyUsed(t *testing.T) {
+	users := newFakeUsers()
+	c := NewCachedUserDataAdapter(users, time.Hour, 2)
+
+	getContact(t, c, "a")
+	getContact(t, c, "b")
+	getContact(t, c, "a") // a is now more recent than b
+	getContact(t, c, "c") // evicts b
+
+	if c.Len() != 2 {
+		t.Errorf("Len = %d, want 2", c.Len())
+	}
+
+	getContact(t, c, "a")
+	getContact(t, c, "b")
+
+	if users.count("a") != 1 || users.count("b") != 2 || users.count("c") != 1 {
+		t.Errorf("upstream calls a=%d b=%d c=%d, want 1, 2, 1", users.count("a"), users.count("b"), users.count("c"))
+	}
+}
+
+func TestCachedUserDataAdapterReturnsCopies(t *testing.T) {
+	c := NewCachedUserDataAdapter(newFakeUsers(), time.Hour, 10)
+
+	info := getContact(t, c, "u1")
+	*info.Phone = "changed"
+	info.Email = "changed"
+
+	again := getContact(t, c, "u1")
+	if again.Email != "u1@example.com" || *again.Phone != "+2348031234567" {
+		t.Errorf("cached info changed through a returned copy: %+v, %s", again, *again.Phone)
+	}
+}
+
+func TestCachedUserDataAdapterDoesNotCacheErrors(t *testing.T) {
+	users := newFakeUsers()
+	users.err = errors.New("user service down")
+	c := NewCachedUserDataAdapter(users, time.Hour, 10)
+
+	if _, err := c.GetContactInfo(context.Background(), "u1"); !errors.Is(err, users.err) {
+		t.Fatalf("err = %v, want %v", err, users.err)
+	}
+
+	users.mu.Lock()
+	users.err = nil
+	users.mu.Unlock()
+
+	getContact(t, c, "u1")
+	if got := users.count("u1"); got != 2 {
+		t.Errorf("upstream calls = %d, want 2", got)
+	}
+}
+
+func TestCachedUserDataAdapterCoalescesLookups(t *testing.T) {
+	users := newFakeUsers()
+	users.block = make(chan struct{})
+	users.started = make(chan string, 1)
+	c := NewCachedUserDataAdapter(users, time.Hour, 10)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info, err := c.GetContactInfo(context.Background(), "u1")
+			if err == nil && info.Email != "u1@example.com" {
+				err = errors.New("wrong contact info " + info.Email)
+			}
+			errs <- err
+		}()
+	}
+
+	<-users.started
+
+	// A waiter giving up doesn't affect the others
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetContactInfo(ctx, "u1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled waiter: err = %v", err)
+	}
+
+	close(users.block)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if got := users.count("u1"); got != 1 {
+		t.Errorf("upstream calls = %d, want 1", got)
+	}
+}
+
+func TestCachedUserDataAdapterInvalidate(t *testing.T) {
+	users := newFakeUsers()
+	c := NewCachedUserDataAdapter(users, time.Hour, 10)
+
+	getContact(t, c, "u1")
+	users.setEmail("u1", "new@example.com")
+	c.Invalidate("u1")
+
+	if got := getContact(t, c, "u1").Email; got != "new@example.com" {
+		t.Errorf("email after Invalidate = %s", got)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len = %d, want 1", c.Len())
+	}
+
+	// Invalidating a user that isn't cached is a no-op
+	c.Invalidate("nobody")
+}
+
+func TestCachedUserDataAdapterInvalidateDuringLookup(t *testing.T) {
+	users := newFakeUsers()
+	block := make(chan struct{})
+	users.block = block
+	users.started = make(chan string, 1)
+	c := NewCachedUserDataAdapter(users, time.Hour, 10)
+
+	done := make(chan *domain.UserContactInfo)
+	go func() {
+		info, _ := c.GetContactInfo(context.Background(), "u1")
+		done <- info
+	}()
+
+	// The change lands while a lookup that read the old value is in flight
+	<-users.started
+	users.mu.Lock()
+	users.block, users.started = nil, nil
+	users.mu.Unlock()
+	users.setEmail("u1", "new@example.com")
+	c.Invalidate("u1")
+	close(block)
+
+	if stale := <-done; stale == nil || stale.Email != "u1@example.com" {
+		t.Fatalf("in-flight lookup = %+v", stale)
+	}
+
+	if got := getContact(t, c, "u1").Email; got != "new@example.com" {
+		t.Errorf("email after the race = %s, want the stale answer not cached", got)
+	}
+	if got := users.count("u1"); got != 2 {
+		t.Errorf("upstream calls = %d, want 2", got)
+	}
+
+	// Later lookups are cached again
+	getContact(t, c, "u1")
+	if got := users.count("u1"); got != 2 {
+		t.Errorf("upstream calls = %d, want 2", got)
+	}
+}
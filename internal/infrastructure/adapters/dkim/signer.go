@@ -0,0 +1,279 @@
+package dkim
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	config "github.com/commitshark/notification-svc/internal"
+)
+
+const (
+	AlgorithmRSA     = "rsa-sha256"
+	AlgorithmEd25519 = "ed25519-sha256"
+)
+
+// defaultSignedHeaders are signed when present. "from" is listed twice so a second
+// From header can't be added without breaking the signature.
+var defaultSignedHeaders = []string{
+	"from", "from", "to", "cc", "reply-to", "subject", "date", "message-id",
+	"mime-version", "content-type", "list-unsubscribe", "list-unsubscribe-post",
+}
+
+var wsp = regexp.MustCompile(`[ \t]+`)
+
+// Key is one selector's private key
+type Key struct {
+	Selector  string
+	Algorithm string
+	signer    crypto.Signer
+}
+
+// Signer adds one DKIM-Signature per key, newest key first, so a new selector can
+// be published and rolled out before the old one is removed.
+type Signer struct {
+	domain  string
+	keys    []Key
+	headers []string
+	now     func() time.Time
+}
+
+func NewSigner(domain string, keys ...Key) (*Signer, error) {
+	if domain == "" {
+		return nil, errors.New("dkim: domain is required")
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("dkim: at least one key is required")
+	}
+
+	return &Signer{
+		domain:  domain,
+		keys:    keys,
+		headers: defaultSignedHeaders,
+		now:     time.Now,
+	}, nil
+}
+
+// NewSignerFromConfig returns nil when DKIM isn't configured for the identity
+func NewSignerFromConfig(cfg config.DKIMConfig) (*Signer, error) {
+	selectors := cfg.Keys
+	if cfg.Selector != "" {
+		selectors = append([]config.DKIMKeyConfig{{Selector: cfg.Selector, PrivateKeyPath: cfg.PrivateKeyPath}}, selectors...)
+	}
+
+	if cfg.Domain == "" && len(selectors) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]Key, 0, len(selectors))
+	for _, s := range selectors {
+		key, err := LoadKey(s.Selector, s.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return NewSigner(cfg.Domain, keys...)
+}
+
+// LoadKey reads a PEM encoded RSA (PKCS#1 or PKCS#8) or Ed25519 (PKCS#8) key
+func LoadKey(selector, path string) (Key, error) {
+	if selector == "" {
+		return Key{}, errors.New("dkim: selector is required")
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Key{}, fmt.Errorf("dkim: failed to read key for selector %s: %w", selector, err)
+	}
+
+	return ParseKey(selector, raw)
+}
+
+func ParseKey(selector string, pemBytes []byte) (Key, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return Key{}, fmt.Errorf("dkim: no PEM block in key for selector %s", selector)
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return Key{}, fmt.Errorf("dkim: invalid key for selector %s: %w", selector, err)
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		return Key{Selector: selector, Algorithm: AlgorithmRSA, signer: k}, nil
+	case ed25519.PrivateKey:
+		return Key{Selector: selector, Algorithm: AlgorithmEd25519, signer: k}, nil
+	default:
+		return Key{}, fmt.Errorf("dkim: unsupported key type %T for selector %s", parsed, selector)
+	}
+}
+
+// Sign returns message with DKIM-Signature headers prepended. The message must
+// use CRLF line endings, as produced by the MIME builder.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	headerEnd := bytes.Index(message, []byte("\r\n\r\n"))
+	if headerEnd < 0 {
+		return nil, errors.New("dkim: message has no header/body separator")
+	}
+
+	headers := splitHeaders(string(message[:headerEnd+2]))
+	body := message[headerEnd+4:]
+
+	bodyHash := sha256.Sum256(relaxedBody(body))
+	bh := base64.StdEncoding.EncodeToString(bodyHash[:])
+
+	signedNames, signedData := s.selectHeaders(headers)
+
+	var out bytes.Buffer
+	for _, key := range s.keys {
+		field, err := s.signature(key, bh, signedNames, signedData)
+		if err != nil {
+			return nil, err
+		}
+		out.WriteString(field)
+	}
+	out.Write(message)
+
+	return out.Bytes(), nil
+}
+
+func (s *Signer) signature(key Key, bodyHash string, names []string, signedData string) (string, error) {
+	value := fmt.Sprintf(
+		" v=1; a=%s; c=relaxed/relaxed; d=%s; s=%s;\r\n\tt=%s; h=%s;\r\n\tbh=%s;\r\n\tb=",
+		key.Algorithm, s.domain, key.Selector,
+		strconv.FormatInt(s.now().Unix(), 10), strings.Join(names, ":"), bodyHash,
+	)
+
+	// The signature covers the signed headers plus this header with an empty b=
+	// and no trailing CRLF
+	data := signedData + strings.TrimSuffix(relaxedHeader("DKIM-Signature", value), "\r\n")
+	digest := sha256.Sum256([]byte(data))
+
+	var sig []byte
+	var err error
+	switch key.Algorithm {
+	case AlgorithmEd25519:
+		// RFC 8463: Ed25519 signs the SHA-256 hash
+		sig, err = key.signer.Sign(rand.Reader, digest[:], crypto.Hash(0))
+	default:
+		sig, err = key.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return "", fmt.Errorf("dkim: signing with selector %s failed: %w", key.Selector, err)
+	}
+
+	return "DKIM-Signature:" + value + foldBase64(base64.StdEncoding.EncodeToString(sig)) + "\r\n", nil
+}
+
+// selectHeaders picks the configured headers, bottom-up for repeated names as RFC
+// 6376 §5.4.2 requires. Names not present are still listed once they run out, which
+// signs their absence.
+func (s *Signer) selectHeaders(headers []header) ([]string, string) {
+	used := map[string]int{}
+	var names []string
+	var data strings.Builder
+
+	for _, name := range s.headers {
+		var matches []header
+		for _, h := range headers {
+			if strings.EqualFold(h.name, name) {
+				matches = append(matches, h)
+			}
+		}
+
+		n := used[name]
+		used[name] = n + 1
+
+		if n < len(matches) {
+			h := matches[len(matches)-1-n]
+			data.WriteString(relaxedHeader(h.name, h.value))
+			names = append(names, name)
+		} else if n == len(matches) && n > 0 {
+			names = append(names, name)
+		}
+	}
+
+	return names, data.String()
+}
+
+type header struct {
+	name  string
+	value string
+}
+
+func splitHeaders(block string) []header {
+	var headers []header
+	for _, line := range strings.SplitAfter(block, "\r\n") {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(headers) > 0 {
+			headers[len(headers)-1].value += line
+			continue
+		}
+		name, value, _ := strings.Cut(line, ":")
+		headers = append(headers, header{name: name, value: value})
+	}
+	return headers
+}
+
+// relaxedHeader implements the "relaxed" header canonicalization (RFC 6376 §3.4.2)
+func relaxedHeader(name, value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "")
+	value = wsp.ReplaceAllString(value, " ")
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + strings.TrimSpace(value) + "\r\n"
+}
+
+// relaxedBody implements the "relaxed" body canonicalization (RFC 6376 §3.4.4)
+func relaxedBody(body []byte) []byte {
+	lines := strings.Split(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n")
+
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(wsp.ReplaceAllString(l, " "), " ")
+	}
+
+	// Ignore empty lines at the end of the body
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func foldBase64(s string) string {
+	var b strings.Builder
+	for len(s) > 72 {
+		b.WriteString(s[:72])
+		b.WriteString("\r\n\t ")
+		s = s[72:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
@@ -0,0 +1,222 @@
+package dkim
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	msgauth "github.com/emersion/go-msgauth/dkim"
+)
+
+const testMessage = "From: Events <events@example.com>\r\n" +
+	"To: a@example.com\r\n" +
+	"Subject:  Your   ticket\r\n" +
+	"\tis ready\r\n" +
+	"Date: Sat, 14 Mar 2026 18:00:00 +0100\r\n" +
+	"Message-ID: <1@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: text/plain; charset=\"UTF-8\"\r\n" +
+	"\r\n" +
+	"Hello  there \r\n" +
+	"\r\n" +
+	"See you\r\n" +
+	"\r\n"
+
+func TestSignVerifies(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaPKCS8, _ := x509.MarshalPKCS8PrivateKey(rsaKey)
+	edPKCS8, _ := x509.MarshalPKCS8PrivateKey(edKey)
+
+	records := map[string]string{}
+	parse := func(selector, pemType string, der []byte, record string) Key {
+		key, err := ParseKey(selector, pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: der}))
+		if err != nil {
+			t.Fatalf("ParseKey(%s): %v", selector, err)
+		}
+		records[selector+"._domainkey.example.com"] = record
+		return key
+	}
+
+	rsaPublic, _ := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	rsaRecord := "v=DKIM1; k=rsa; p=" + base64.StdEncoding.EncodeToString(rsaPublic)
+	edRecord := "v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey))
+
+	tests := []struct {
+		name      string
+		keys      []Key
+		algorithm []string
+	}{
+		{"rsa pkcs1", []Key{parse("rsa1", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey), rsaRecord)}, []string{AlgorithmRSA}},
+		{"rsa pkcs8", []Key{parse("rsa8", "PRIVATE KEY", rsaPKCS8, rsaRecord)}, []string{AlgorithmRSA}},
+		{"ed25519", []Key{parse("ed", "PRIVATE KEY", edPKCS8, edRecord)}, []string{AlgorithmEd25519}},
+		{"rotation", []Key{
+			parse("new", "PRIVATE KEY", edPKCS8, edRecord),
+			parse("old", "PRIVATE KEY", rsaPKCS8, rsaRecord),
+		}, []string{AlgorithmEd25519, AlgorithmRSA}},
+	}
+
+	lookup := func(domain string) ([]string, error) {
+		if record, ok := records[domain]; ok {
+			return []string{record}, nil
+		}
+		return nil, fmt.Errorf("no record for %s", domain)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewSigner("example.com", tt.keys...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signer.now = func() time.Time { return time.Unix(1773500000, 0) }
+
+			signed, err := signer.Sign([]byte(testMessage))
+			if err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			if !bytes.HasSuffix(signed, []byte(testMessage)) {
+				t.Fatal("Sign changed the message")
+			}
+
+			verifications, err := msgauth.VerifyWithOptions(bytes.NewReader(signed), &msgauth.VerifyOptions{LookupTXT: lookup})
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if len(verifications) != len(tt.keys) {
+				t.Fatalf("%d signatures, want %d", len(verifications), len(tt.keys))
+			}
+			for i, v := range verifications {
+				if v.Err != nil {
+					t.Errorf("signature %d: %v", i, v.Err)
+				}
+				if v.Domain != "example.com" {
+					t.Errorf("signature %d domain = %s", i, v.Domain)
+				}
+			}
+
+			for i, want := range tt.algorithm {
+				if !strings.Contains(signatureField(signed, i), "a="+want+";") {
+					t.Errorf("signature %d is not %s", i, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSignDetectsTampering(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	signer, err := NewSigner("example.com", Key{Selector: "ed", Algorithm: AlgorithmEd25519, signer: edKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := "v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey))
+	lookup := func(string) ([]string, error) { return []string{record}, nil }
+
+	signed, err := signer.Sign([]byte(testMessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(string) string
+		valid  bool
+	}{
+		{"whitespace in headers", func(m string) string { return strings.Replace(m, "Subject:  Your", "Subject: Your ", 1) }, true},
+		{"trailing blank lines", func(m string) string { return m + "\r\n\r\n" }, true},
+		{"body", func(m string) string { return strings.Replace(m, "See you", "See me", 1) }, false},
+		{"subject", func(m string) string { return strings.Replace(m, "ticket", "refund", 1) }, false},
+		{"second from", func(m string) string { return "From: attacker@example.net\r\n" + m }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifications, err := msgauth.VerifyWithOptions(strings.NewReader(tt.change(string(signed))), &msgauth.VerifyOptions{LookupTXT: lookup})
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if valid := len(verifications) == 1 && verifications[0].Err == nil; valid != tt.valid {
+				t.Errorf("valid = %v, want %v (%v)", valid, tt.valid, verifications)
+			}
+		})
+	}
+}
+
+func TestRelaxedCanonicalization(t *testing.T) {
+	// RFC 6376 §3.4.5
+	headers := splitHeaders("A: X\r\nB : Y\t\r\n\tZ  \r\n")
+	var got strings.Builder
+	for _, h := range headers {
+		got.WriteString(relaxedHeader(h.name, h.value))
+	}
+	if got.String() != "a:X\r\nb:Y Z\r\n" {
+		t.Errorf("relaxed headers = %q", got.String())
+	}
+
+	tests := []struct {
+		body string
+		want string
+	}{
+		{" C \r\nD \t E\r\n\r\n\r\n", " C\r\nD E\r\n"},
+		{"", ""},
+		{"\r\n\r\n", ""},
+		{"no newline", "no newline\r\n"},
+	}
+
+	for _, tt := range tests {
+		if got := string(relaxedBody([]byte(tt.body))); got != tt.want {
+			t.Errorf("relaxedBody(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestParseKeyRejects(t *testing.T) {
+	ecKey := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("junk")})
+
+	tests := []struct {
+		name string
+		pem  []byte
+	}{
+		{"not pem", []byte("not a key")},
+		{"invalid key", ecKey},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParseKey("s", tt.pem); err == nil {
+			t.Errorf("ParseKey(%s) accepted the key", tt.name)
+		}
+	}
+
+	if _, err := NewSigner("", Key{}); err == nil {
+		t.Error("NewSigner accepted an empty domain")
+	}
+	if _, err := NewSigner("example.com"); err == nil {
+		t.Error("NewSigner accepted no keys")
+	}
+}
+
+// signatureField returns the i-th DKIM-Signature header of a signed message
+func signatureField(message []byte, i int) string {
+	fields := strings.Split(string(message), "DKIM-Signature:")
+	if i+1 >= len(fields) {
+		return ""
+	}
+	return fields[i+1]
+}
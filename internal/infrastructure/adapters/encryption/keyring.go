@@ -0,0 +1,262 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// keyFile is the format of the local keyfile:
+//
+//	{
+//	  "current_key": "2026-10",
+//	  "keys": {"2026-10": "<base64 32 bytes>", "2026-01": "<base64 32 bytes>"},
+//	  "index_key": "<base64 32 bytes>"
+//	}
+//
+// New rows are encrypted with current_key; older keys stay listed until their
+// rows are re-encrypted. index_key keys the blind indexes and never rotates.
+type keyFile struct {
+	CurrentKey string            `json:"current_key"`
+	Keys       map[string]string `json:"keys"`
+	IndexKey   string            `json:"index_key"`
+}
+
+// Keyring encrypts notification fields with AES-256-GCM. A nil Keyring leaves
+// them in plaintext.
+type Keyring struct {
+	current  string
+	aeads    map[string]cipher.AEAD
+	indexKey []byte
+}
+
+func LoadKeyring(path string) (*Keyring, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyfile: %w", err)
+	}
+
+	var file keyFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse keyfile: %w", err)
+	}
+
+	if _, ok := file.Keys[file.CurrentKey]; !ok {
+		return nil, fmt.Errorf("current key %q is not in the keyfile", file.CurrentKey)
+	}
+
+	k := &Keyring{
+		current: file.CurrentKey,
+		aeads:   make(map[string]cipher.AEAD, len(file.Keys)),
+	}
+
+	for id, encoded := range file.Keys {
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		k.aeads[id] = aead
+	}
+
+	if k.indexKey, err = decodeKey(file.IndexKey); err != nil {
+		return nil, fmt.Errorf("index key: %w", err)
+	}
+
+	return k, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// CurrentKeyID is the key new rows are encrypted with, empty without a keyring
+func (k *Keyring) CurrentKeyID() string {
+	if k == nil {
+		return ""
+	}
+	return k.current
+}
+
+// BlindIndex is a keyed hash of value that can be matched exactly without
+// decrypting anything. Matching is case-insensitive.
+func (k *Keyring) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Fields are the columns of a notification row encrypted at rest
+type Fields struct {
+	Email       *string
+	Phone       *string
+	Device      *string
+	Title       *string
+	Body        *string
+	Data        *string
+	HTML        *string
+	Attachments *string
+}
+
+type column struct {
+	name  string
+	value **string
+}
+
+func (f *Fields) columns() []column {
+	return []column{
+		{"recipient_email", &f.Email},
+		{"recipient_phone", &f.Phone},
+		{"recipient_device", &f.Device},
+		{"title", &f.Title},
+		{"body", &f.Body},
+		{"data", &f.Data},
+		{"html", &f.HTML},
+		{"attachments", &f.Attachments},
+	}
+}
+
+// Sealed is what gets stored for a row: its fields, the ID of the key they are
+// encrypted with and the blind indexes of the recipient's email and phone.
+type Sealed struct {
+	Fields
+	KeyID      *string
+	EmailIndex *string
+	PhoneIndex *string
+}
+
+// Seal encrypts the fields of row id with the current key. Each ciphertext is
+// bound to its row and column, so it can't be moved to another one.
+func (k *Keyring) Seal(id string, f Fields) (Sealed, error) {
+	if k == nil {
+		return Sealed{Fields: f}, nil
+	}
+
+	sealed := Sealed{KeyID: &k.current}
+	if f.Email != nil {
+		index := k.BlindIndex(*f.Email)
+		sealed.EmailIndex = &index
+	}
+	if f.Phone != nil {
+		index := k.BlindIndex(*f.Phone)
+		sealed.PhoneIndex = &index
+	}
+
+	for _, c := range f.columns() {
+		if *c.value == nil {
+			continue
+		}
+
+		encoded, err := k.SealValue(id, c.name, **c.value)
+		if err != nil {
+			return Sealed{}, err
+		}
+		*c.value = &encoded
+	}
+
+	sealed.Fields = f
+	return sealed, nil
+}
+
+// SealValue encrypts one value of row id with the current key, bound to the
+// row and to column
+func (k *Keyring) SealValue(id, column, value string) (string, error) {
+	aead := k.aeads[k.current]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	ciphertext := aead.Seal(nonce, nonce, []byte(value), associatedData(id, column))
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Open decrypts fields sealed under keyID. Fields of rows without a key ID
+// were stored in plaintext and are returned as they are.
+func (k *Keyring) Open(id, keyID string, f Fields) (Fields, error) {
+	if keyID == "" {
+		return f, nil
+	}
+	if k == nil {
+		return Fields{}, fmt.Errorf("notification %s is encrypted but no keyfile is configured", id)
+	}
+
+	for _, c := range f.columns() {
+		if *c.value == nil {
+			continue
+		}
+
+		value, err := k.OpenValue(id, keyID, c.name, **c.value)
+		if err != nil {
+			return Fields{}, err
+		}
+		*c.value = &value
+	}
+
+	return f, nil
+}
+
+// OpenLegacy is Open for rows sealed before title and attachments were
+// encrypted, which still hold those two in plaintext
+func (k *Keyring) OpenLegacy(id, keyID string, f Fields) (Fields, error) {
+	title, attachments := f.Title, f.Attachments
+	f.Title, f.Attachments = nil, nil
+
+	opened, err := k.Open(id, keyID, f)
+	if err != nil {
+		return Fields{}, err
+	}
+
+	opened.Title, opened.Attachments = title, attachments
+	return opened, nil
+}
+
+// OpenValue decrypts one value of row id sealed under keyID for column
+func (k *Keyring) OpenValue(id, keyID, column, value string) (string, error) {
+	aead, ok := k.aeads[keyID]
+	if !ok {
+		return "", fmt.Errorf("notification %s is encrypted with unknown key %q", id, keyID)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("notification %s has a malformed %s", id, column)
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, associatedData(id, column))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s of notification %s: %w", column, id, err)
+	}
+
+	return string(plaintext), nil
+}
+
+func associatedData(id, column string) []byte {
+	return []byte(id + "/" + column)
+}
@@ -0,0 +1,217 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newKey() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func writeKeyfile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// loadKeyring loads a keyring with the given key IDs and keys, current first
+func loadKeyring(t *testing.T, indexKey string, keys ...string) *Keyring {
+	t.Helper()
+
+	var entries []string
+	for i := 0; i < len(keys); i += 2 {
+		entries = append(entries, fmt.Sprintf("%q: %q", keys[i], keys[i+1]))
+	}
+	content := fmt.Sprintf(`{"current_key": %q, "keys": {%s}, "index_key": %q}`, keys[0], strings.Join(entries, ", "), indexKey)
+
+	k, err := LoadKeyring(writeKeyfile(t, content))
+	if err != nil {
+		t.Fatalf("LoadKeyring: %v", err)
+	}
+	return k
+}
+
+func TestLoadKeyringRejects(t *testing.T) {
+	key := newKey()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not json", `current_key = k1`},
+		{"unknown current key", fmt.Sprintf(`{"current_key": "k2", "keys": {"k1": %q}, "index_key": %q}`, key, key)},
+		{"no keys", fmt.Sprintf(`{"current_key": "", "index_key": %q}`, key)},
+		{"bad base64", fmt.Sprintf(`{"current_key": "k1", "keys": {"k1": "not base64!"}, "index_key": %q}`, key)},
+		{"short key", fmt.Sprintf(`{"current_key": "k1", "keys": {"k1": %q}, "index_key": %q}`, base64.StdEncoding.EncodeToString([]byte("short")), key)},
+		{"missing index key", fmt.Sprintf(`{"current_key": "k1", "keys": {"k1": %q}}`, key)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadKeyring(writeKeyfile(t, tt.content)); err == nil {
+				t.Error("LoadKeyring accepted the keyfile")
+			}
+		})
+	}
+
+	if _, err := LoadKeyring(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadKeyring accepted a missing keyfile")
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	k := loadKeyring(t, newKey(), "k1", newKey())
+
+	email, phone, title, body := "Ada@Example.com", "+2348031234567", "Your ticket", "See you there"
+	sealed, err := k.Seal("n1", Fields{Email: &email, Phone: &phone, Title: &title, Body: &body})
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	if sealed.KeyID == nil || *sealed.KeyID != "k1" {
+		t.Errorf("key ID = %v, want k1", sealed.KeyID)
+	}
+	if *sealed.Email == email || *sealed.Title == title || *sealed.Body == body {
+		t.Error("fields were stored in plaintext")
+	}
+	if sealed.Data != nil || sealed.HTML != nil || sealed.Device != nil || sealed.Attachments != nil {
+		t.Error("unset fields were filled in")
+	}
+	if *sealed.EmailIndex != k.BlindIndex(" ada@example.COM ") || *sealed.PhoneIndex != k.BlindIndex(phone) {
+		t.Error("blind indexes don't match the normalized values")
+	}
+
+	again, _ := k.Seal("n1", Fields{Email: &email})
+	if *again.Email == *sealed.Email {
+		t.Error("sealing the same value twice gave the same ciphertext")
+	}
+
+	opened, err := k.Open("n1", *sealed.KeyID, sealed.Fields)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if *opened.Email != email || *opened.Phone != phone || *opened.Title != title || *opened.Body != body || opened.Data != nil {
+		t.Errorf("opened = %+v", opened)
+	}
+}
+
+func TestOpenRejectsMovedCiphertext(t *testing.T) {
+	k := loadKeyring(t, newKey(), "k1", newKey())
+
+	email, phone := "a@example.com", "+2348031234567"
+	sealed, err := k.Seal("n1", Fields{Email: &email, Phone: &phone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	malformed := "not base64!"
+
+	tests := []struct {
+		name   string
+		id     string
+		fields Fields
+	}{
+		{"other row", "n2", Fields{Email: sealed.Email}},
+		{"other column", "n1", Fields{Phone: sealed.Email}},
+		{"malformed", "n1", Fields{Email: &malformed}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if opened, err := k.Open(tt.id, "k1", tt.fields); err == nil {
+				t.Errorf("Open = %+v, want an error", opened)
+			}
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	indexKey, oldKey := newKey(), newKey()
+	before := loadKeyring(t, indexKey, "2026-01", oldKey)
+
+	email := "a@example.com"
+	old, err := before.Seal("n1", Fields{Email: &email})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after := loadKeyring(t, indexKey, "2026-10", newKey(), "2026-01", oldKey)
+
+	opened, err := after.Open("n1", *old.KeyID, old.Fields)
+	if err != nil || *opened.Email != email {
+		t.Fatalf("Open with the retired key = %v, %v", opened.Email, err)
+	}
+
+	resealed, err := after.Seal("n1", opened)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *resealed.KeyID != "2026-10" || after.CurrentKeyID() != "2026-10" {
+		t.Errorf("resealed under %s, want 2026-10", *resealed.KeyID)
+	}
+	// The index key doesn't rotate, so searches keep matching
+	if *resealed.EmailIndex != *old.EmailIndex {
+		t.Error("blind index changed with the encryption key")
+	}
+
+	dropped := loadKeyring(t, indexKey, "2026-10", newKey())
+	if _, err := dropped.Open("n1", *old.KeyID, old.Fields); err == nil || !strings.Contains(err.Error(), "unknown key") {
+		t.Errorf("Open after the key was dropped: err = %v", err)
+	}
+}
+
+func TestNilKeyring(t *testing.T) {
+	var k *Keyring
+
+	email := "a@example.com"
+	sealed, err := k.Seal("n1", Fields{Email: &email})
+	if err != nil || sealed.KeyID != nil || *sealed.Email != email || sealed.EmailIndex != nil {
+		t.Errorf("Seal without a keyring = %+v, %v", sealed, err)
+	}
+	if k.CurrentKeyID() != "" {
+		t.Errorf("CurrentKeyID = %q", k.CurrentKeyID())
+	}
+
+	if opened, err := k.Open("n1", "", sealed.Fields); err != nil || *opened.Email != email {
+		t.Errorf("Open of a plaintext row = %+v, %v", opened, err)
+	}
+	if _, err := k.Open("n1", "k1", sealed.Fields); err == nil {
+		t.Error("Open of an encrypted row without a keyring succeeded")
+	}
+}
+
+func TestOpenLegacy(t *testing.T) {
+	k := loadKeyring(t, newKey(), "k1", newKey())
+
+	email, title, attachments := "a@example.com", "Hello", `[{"filename":"a.pdf"}]`
+	sealed, err := k.Seal("n1", Fields{Email: &email})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rows sealed before titles and attachments were encrypted
+	sealed.Title, sealed.Attachments = &title, &attachments
+
+	opened, err := k.OpenLegacy("n1", "k1", sealed.Fields)
+	if err != nil {
+		t.Fatalf("OpenLegacy: %v", err)
+	}
+	if *opened.Email != email || *opened.Title != title || *opened.Attachments != attachments {
+		t.Errorf("opened = %+v", opened)
+	}
+
+	if _, err := k.Open("n1", "k1", sealed.Fields); err == nil {
+		t.Error("Open decrypted a plaintext title")
+	}
+}
@@ -2,22 +2,114 @@ package grpcclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"strings"
+	"time"
 
 	pb "github.com/commitshark/notification-svc/gen"
 	"github.com/commitshark/notification-svc/internal/domain"
 	"github.com/commitshark/notification-svc/internal/domain/ports"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultCallTimeout = 3 * time.Second
+	maxAttempts        = 3
+	baseBackoff        = 100 * time.Millisecond
 )
 
 type userDataGRPCClient struct {
 	client pb.GrpcUserServiceClient
+	// timeout bounds each attempt; retries get a fresh deadline
+	timeout time.Duration
 }
 
-func NewUserDataGRPCClient(conn *grpc.ClientConn) ports.UserDataAdapter {
+func NewUserDataGRPCClient(conn *grpc.ClientConn, timeout time.Duration) ports.UserDataAdapter {
+	if timeout <= 0 {
+		timeout = DefaultCallTimeout
+	}
+
 	return &userDataGRPCClient{
-		client: pb.NewGrpcUserServiceClient(conn),
+		client:  pb.NewGrpcUserServiceClient(conn),
+		timeout: timeout,
+	}
+}
+
+// invoke runs call with a per-attempt deadline, retrying with backoff while the
+// user service is unavailable or slow. Exhausted retries return
+// domain.ErrUserServiceUnavailable.
+func invoke[T any](ctx context.Context, timeout time.Duration, method string, call func(context.Context) (T, error)) (T, error) {
+	var zero T
+
+	for attempt := 1; ; attempt++ {
+		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		resp, err := call(callCtx)
+		cancel()
+		if err == nil {
+			return resp, nil
+		}
+
+		code := status.Code(err)
+		transient := code == codes.Unavailable || code == codes.DeadlineExceeded
+		if !transient {
+			return zero, fmt.Errorf("%s failed: %w", method, err)
+		}
+
+		if attempt >= maxAttempts || ctx.Err() != nil {
+			return zero, fmt.Errorf("%w: %s after %d attempts: %v", domain.ErrUserServiceUnavailable, method, attempt, err)
+		}
+
+		// Exponential backoff with jitter: ~100ms, ~200ms, ...
+		backoff := baseBackoff << (attempt - 1)
+		backoff += time.Duration(rand.Int63n(int64(backoff)))
+
+		select {
+		case <-ctx.Done():
+			return zero, fmt.Errorf("%w: %s: %v", domain.ErrUserServiceUnavailable, method, ctx.Err())
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// userError maps a failed user service call to a domain error by its status
+// code, falling back to the message for codes that don't tell
+func userError(userID string, err error) error {
+	if errors.Is(err, domain.ErrUserServiceUnavailable) {
+		return err
+	}
+
+	switch status.Code(err) {
+	case codes.NotFound:
+		return fmt.Errorf("%w: %s: %v", domain.ErrUserNotFound, userID, err)
+	case codes.PermissionDenied:
+		return fmt.Errorf("%w: %s: %v", domain.ErrUserBlocked, userID, err)
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return fmt.Errorf("%w: %s: %v", domain.ErrUserServiceUnavailable, userID, err)
+	}
+
+	return userMessageError(userID, status.Convert(err).Message())
+}
+
+// userMessageError maps an error message from the user service to a domain
+// error. It is the last resort, for errors without a telling status code.
+func userMessageError(userID, message string) error {
+	lower := strings.ToLower(message)
+
+	switch {
+	case strings.Contains(lower, "not found"), strings.Contains(lower, "does not exist"), strings.Contains(lower, "no such user"):
+		return fmt.Errorf("%w: %s: %s", domain.ErrUserNotFound, userID, message)
+	case strings.Contains(lower, "deleted"):
+		return fmt.Errorf("%w: %s: %s", domain.ErrUserDeleted, userID, message)
+	case strings.Contains(lower, "blocked"), strings.Contains(lower, "banned"), strings.Contains(lower, "suspended"), strings.Contains(lower, "disabled"):
+		return fmt.Errorf("%w: %s: %s", domain.ErrUserBlocked, userID, message)
+	default:
+		// Unrecognized errors may be temporary on the user service's side
+		return fmt.Errorf("%w: %s: %s", domain.ErrUserServiceUnavailable, userID, message)
 	}
 }
 
@@ -26,14 +118,77 @@ func (c *userDataGRPCClient) GetContactInfo(ctx context.Context, userID string)
 		UserAuthId: userID,
 	}
 
-	resp, err := c.client.GetUserContactInfo(ctx, req)
+	resp, err := invoke(ctx, c.timeout, "GetUserContactInfo", func(ctx context.Context) (*pb.GetUserContactInfoResponse, error) {
+		return c.client.GetUserContactInfo(ctx, req)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch user contact info: %w", err)
+		return nil, fmt.Errorf("failed to fetch user contact info: %w", userError(userID, err))
+	}
+
+	if resp.Error != nil {
+		return nil, userMessageError(userID, *resp.Error)
 	}
 
 	return &domain.UserContactInfo{
 		Email:    resp.Email,
 		Phone:    resp.Phone,
 		DeviceID: resp.Device,
+		Locale:   resp.GetLocale(),
 	}, nil
 }
+
+func (c *userDataGRPCClient) GetEventOrganizer(ctx context.Context, event string) (string, error) {
+	resp, err := invoke(ctx, c.timeout, "GetEventOrganizer", func(ctx context.Context) (*pb.GetEventOrganizerResponse, error) {
+		return c.client.GetEventOrganizer(ctx, &pb.GetEventOrganizerRequest{Slug: event})
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch event organizer: %w", err)
+	}
+
+	if resp.Error != nil {
+		return "", fmt.Errorf("%w: organizer of %s: %s", domain.ErrRecipientNotResolved, event, *resp.Error)
+	}
+
+	return requireUserID(resp.UserId, "organizer of "+event)
+}
+
+func (c *userDataGRPCClient) GetSystemUser(ctx context.Context) (string, error) {
+	resp, err := invoke(ctx, c.timeout, "GetSystemUser", func(ctx context.Context) (*pb.GetSystemUserResponse, error) {
+		return c.client.GetSystemUser(ctx, &pb.GetSystemUserRequest{})
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch system user: %w", err)
+	}
+
+	if resp.Error != nil {
+		return "", fmt.Errorf("%w: system user: %s", domain.ErrRecipientNotResolved, *resp.Error)
+	}
+
+	return requireUserID(resp.UserId, "system user")
+}
+
+func (c *userDataGRPCClient) GetReferrer(ctx context.Context, userID string) (string, error) {
+	resp, err := invoke(ctx, c.timeout, "GetReferralInfo", func(ctx context.Context) (*pb.GetReferralInfoResponse, error) {
+		return c.client.GetReferralInfo(ctx, &pb.GetReferralInfoRequest{UserId: userID})
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch referral info: %w", err)
+	}
+
+	if resp.Error != nil {
+		return "", fmt.Errorf("%w: referrer of %s: %s", domain.ErrRecipientNotResolved, userID, *resp.Error)
+	}
+
+	if resp.UserId == nil {
+		return "", fmt.Errorf("%w: %s was not referred", domain.ErrRecipientNotResolved, userID)
+	}
+
+	return requireUserID(*resp.UserId, "referrer of "+userID)
+}
+
+func requireUserID(userID, what string) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("%w: no user for %s", domain.ErrRecipientNotResolved, what)
+	}
+	return userID, nil
+}
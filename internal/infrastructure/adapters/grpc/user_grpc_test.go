@@ -0,0 +1,55 @@
+package grpcclient
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUserError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"not found code", status.Error(codes.NotFound, "whatever"), domain.ErrUserNotFound},
+		{"permission denied code", status.Error(codes.PermissionDenied, "nope"), domain.ErrUserBlocked},
+		{"code wins over message", status.Error(codes.NotFound, "user is banned"), domain.ErrUserNotFound},
+		{"wrapped status", fmt.Errorf("GetUserContactInfo failed: %w", status.Error(codes.NotFound, "")), domain.ErrUserNotFound},
+		{"unavailable code", status.Error(codes.ResourceExhausted, "slow down"), domain.ErrUserServiceUnavailable},
+		{"message fallback", status.Error(codes.Internal, "account deleted"), domain.ErrUserDeleted},
+		{"unknown message", status.Error(codes.Internal, "boom"), domain.ErrUserServiceUnavailable},
+		{"already classified", fmt.Errorf("%w: retries exhausted", domain.ErrUserServiceUnavailable), domain.ErrUserServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := userError("u1", tt.err); !errors.Is(got, tt.want) {
+				t.Errorf("userError = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserMessageError(t *testing.T) {
+	tests := []struct {
+		message string
+		want    error
+	}{
+		{"User not found", domain.ErrUserNotFound},
+		{"no such user", domain.ErrUserNotFound},
+		{"user was deleted", domain.ErrUserDeleted},
+		{"account suspended", domain.ErrUserBlocked},
+		{"database timeout", domain.ErrUserServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		if got := userMessageError("u1", tt.message); !errors.Is(got, tt.want) {
+			t.Errorf("userMessageError(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
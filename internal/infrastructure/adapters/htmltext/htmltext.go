@@ -0,0 +1,203 @@
+package htmltext
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	spaces       = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLines   = regexp.MustCompile(`\n{3,}`)
+	hiddenStyles = regexp.MustCompile(`display\s*:\s*none`)
+)
+
+// Convert turns an HTML email into a readable plain-text body. Links keep their
+// target as "text (url)", table cells are separated so rows stay on one line and
+// hidden content such as the preheader is dropped.
+func Convert(document string) string {
+	z := html.NewTokenizer(strings.NewReader(document))
+
+	c := &converter{}
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return c.result()
+
+		case html.TextToken:
+			if c.skipDepth > 0 {
+				continue
+			}
+			c.text(string(z.Text()))
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if c.skipDepth > 0 {
+				if tt == html.StartTagToken && !isVoid(tok.DataAtom) {
+					c.skipDepth++
+				}
+				continue
+			}
+			if skipped(tok) {
+				if tt == html.StartTagToken && !isVoid(tok.DataAtom) {
+					c.skipDepth++
+				}
+				continue
+			}
+			c.open(tok)
+
+		case html.EndTagToken:
+			tok := z.Token()
+			if c.skipDepth > 0 {
+				c.skipDepth--
+				continue
+			}
+			c.close(tok)
+		}
+	}
+}
+
+type link struct {
+	href  string
+	start int
+}
+
+type converter struct {
+	b         strings.Builder
+	skipDepth int
+	links     []link
+	cellOpen  bool
+}
+
+func (c *converter) text(s string) {
+	s = spaces.ReplaceAllString(strings.ReplaceAll(s, "\n", " "), " ")
+	if strings.TrimSpace(s) == "" {
+		if c.b.Len() > 0 && !strings.HasSuffix(c.b.String(), " ") && !strings.HasSuffix(c.b.String(), "\n") {
+			c.b.WriteString(" ")
+		}
+		return
+	}
+	if c.atLineStart() {
+		s = strings.TrimLeft(s, " ")
+	}
+	c.b.WriteString(s)
+}
+
+func (c *converter) open(tok html.Token) {
+	switch tok.DataAtom {
+	case atom.Br:
+		c.b.WriteString("\n")
+	case atom.P, atom.Div, atom.Table, atom.Section, atom.Ul, atom.Ol,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		c.paragraph()
+	case atom.Tr:
+		c.newline()
+		c.cellOpen = false
+	case atom.Td, atom.Th:
+		if c.cellOpen && !c.atLineStart() {
+			c.b.WriteString(" | ")
+		}
+		c.cellOpen = true
+	case atom.Li:
+		c.newline()
+		c.b.WriteString("- ")
+	case atom.Hr:
+		c.paragraph()
+		c.b.WriteString("----------")
+		c.paragraph()
+	case atom.A:
+		c.links = append(c.links, link{href: attr(tok, "href"), start: c.b.Len()})
+	case atom.Img:
+		if alt := strings.TrimSpace(attr(tok, "alt")); alt != "" && !strings.HasPrefix(attr(tok, "src"), "cid:") {
+			c.text(alt)
+		}
+	}
+}
+
+func (c *converter) close(tok html.Token) {
+	switch tok.DataAtom {
+	case atom.P, atom.Div, atom.Table, atom.Section, atom.Ul, atom.Ol,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		c.paragraph()
+	case atom.Tr:
+		c.newline()
+	case atom.A:
+		if len(c.links) == 0 {
+			return
+		}
+		l := c.links[len(c.links)-1]
+		c.links = c.links[:len(c.links)-1]
+
+		label := strings.TrimSpace(c.b.String()[l.start:])
+		if l.href == "" || strings.HasPrefix(l.href, "#") || strings.HasPrefix(l.href, "cid:") || label == l.href {
+			return
+		}
+		href := strings.TrimPrefix(l.href, "mailto:")
+		if label == href {
+			return
+		}
+		if label == "" {
+			c.text(href)
+			return
+		}
+		c.b.WriteString(" (" + href + ")")
+	}
+}
+
+func (c *converter) atLineStart() bool {
+	s := c.b.String()
+	return len(s) == 0 || strings.HasSuffix(s, "\n")
+}
+
+func (c *converter) newline() {
+	if !c.atLineStart() {
+		c.b.WriteString("\n")
+	}
+}
+
+func (c *converter) paragraph() {
+	c.newline()
+	if !strings.HasSuffix(c.b.String(), "\n\n") && c.b.Len() > 0 {
+		c.b.WriteString("\n")
+	}
+}
+
+func (c *converter) result() string {
+	lines := strings.Split(c.b.String(), "\n")
+	for i, l := range lines {
+		l = strings.TrimSpace(l)
+		l = strings.Trim(l, "|")
+		lines[i] = strings.TrimSpace(l)
+	}
+	out := blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(out) + "\n"
+}
+
+func skipped(tok html.Token) bool {
+	switch tok.DataAtom {
+	case atom.Head, atom.Style, atom.Script, atom.Title, atom.Noscript:
+		return true
+	}
+	return hiddenStyles.MatchString(attr(tok, "style"))
+}
+
+func isVoid(a atom.Atom) bool {
+	switch a {
+	case atom.Br, atom.Img, atom.Hr, atom.Meta, atom.Link, atom.Input, atom.Col, atom.Area, atom.Base, atom.Wbr:
+		return true
+	}
+	return false
+}
+
+func attr(tok html.Token, name string) string {
+	for _, a := range tok.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package htmltext
+
+import "testing"
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"plain text", "Hello   world", "Hello world\n"},
+		{"entities", "<p>Fish &amp; chips &lt;3</p>", "Fish & chips <3\n"},
+		{"paragraphs", "<p>One</p><p>Two</p><div>Three</div>", "One\n\nTwo\n\nThree\n"},
+		{"line breaks", "One<br>Two<br/>Three", "One\nTwo\nThree\n"},
+		{"head and scripts", "<html><head><title>T</title><style>p{}</style></head><body><script>x()</script><p>Body</p></body></html>", "Body\n"},
+		{"hidden preheader", `<div style="display: none; max-height:0">Preview text</div><p>Body</p>`, "Body\n"},
+		{"nested hidden", `<div style="display:none"><span>a</span><br><b>b</b></div><p>Body</p>`, "Body\n"},
+		{"link", `<a href="https://example.com/t/1">View ticket</a>`, "View ticket (https://example.com/t/1)\n"},
+		{"bare link", `<a href="https://example.com">https://example.com</a>`, "https://example.com\n"},
+		{"empty link label", `<a href="https://example.com"></a>`, "https://example.com\n"},
+		{"mailto", `<a href="mailto:help@example.com">help@example.com</a>`, "help@example.com\n"},
+		{"anchor", `<a href="#top">Top</a>`, "Top\n"},
+		{"table row", "<table><tr><td>Ticket</td><td>VIP</td></tr><tr><th>Qty</th><th>2</th></tr></table>", "Ticket | VIP\nQty | 2\n"},
+		{"list", "<ul><li>One</li><li>Two</li></ul>", "- One\n- Two\n"},
+		{"image alt", `<img src="https://example.com/a.png" alt="Map"> <img src="cid:logo" alt="Logo">`, "Map\n"},
+		{"rule", "<p>Above</p><hr><p>Below</p>", "Above\n\n----------\n\nBelow\n"},
+		{"blank lines collapse", "<p>One</p><p></p><p></p><div></div><p>Two</p>", "One\n\nTwo\n"},
+		{"empty", "", "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Convert(tt.html); got != tt.want {
+				t.Errorf("Convert(%q) = %q, want %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -17,6 +19,13 @@ import (
 
 const COMMIT_BATCH_SIZE = 2
 
+const (
+	// maxProcessAttempts bounds retries of a failing message before it is dead-lettered
+	maxProcessAttempts = 5
+	baseRetryBackoff   = time.Second
+	maxRetryBackoff    = 30 * time.Second
+)
+
 type KafkaConsumer struct {
 	reader        *kafka.Reader
 	handler       *KafkaMessageHandler
@@ -24,6 +33,8 @@ type KafkaConsumer struct {
 	consumerGroup string
 	brokers       []string
 	logger        *log.Logger
+	// deadLetter is nil without a dead letter topic
+	deadLetter *kafka.Writer
 }
 
 func NewKafkaConsumer(
@@ -43,6 +54,17 @@ func NewKafkaConsumer(
 		ErrorLogger:    kafka.LoggerFunc(logger.Printf),
 	})
 
+	var deadLetter *kafka.Writer
+	if kConfig.DeadLetterTopic != "" {
+		deadLetter = &kafka.Writer{
+			Addr:         kafka.TCP(kConfig.Brokers...),
+			Topic:        kConfig.DeadLetterTopic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			ErrorLogger:  kafka.LoggerFunc(logger.Printf),
+		}
+	}
+
 	return &KafkaConsumer{
 		reader:        reader,
 		handler:       handler,
@@ -50,6 +72,7 @@ func NewKafkaConsumer(
 		consumerGroup: kConfig.ConsumerGroup,
 		brokers:       kConfig.Brokers,
 		logger:        logger,
+		deadLetter:    deadLetter,
 	}
 }
 
@@ -84,11 +107,21 @@ func (c *KafkaConsumer) Start(ctx context.Context) error {
 				continue
 			}
 
-			// Process message
-			if err := c.processMessage(ctx, msg); err != nil {
-				c.logger.Printf("Failed to process message: %v", err)
-				// TODO: send to DLQ
-				continue
+			// Process message, retrying transient failures
+			if err := c.processWithRetry(ctx, msg); err != nil {
+				if ctx.Err() != nil {
+					return nil // leave it uncommitted for the next consumer
+				}
+				if dlqErr := c.sendToDeadLetter(ctx, msg, err); dlqErr != nil {
+					// Committing would lose the message, and a later commit on this
+					// partition would too, so stop here and let it be redelivered
+					if len(toCommit) > 0 {
+						if err := c.reader.CommitMessages(ctx, toCommit[len(toCommit)-1]); err != nil {
+							c.logger.Printf("Failed to commit message: %v", err)
+						}
+					}
+					return fmt.Errorf("failed to dead-letter message at offset %d: %w", msg.Offset, dlqErr)
+				}
 			}
 
 			// Batch Commit offset
@@ -105,24 +138,93 @@ func (c *KafkaConsumer) Start(ctx context.Context) error {
 	}
 }
 
+// processWithRetry processes msg, backing off between attempts, until it
+// succeeds, fails permanently or runs out of attempts. Without a dead letter
+// topic transient failures are retried until ctx is done, since giving up
+// would drop the message.
+func (c *KafkaConsumer) processWithRetry(ctx context.Context, msg kafka.Message) error {
+	backoff := baseRetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := c.processMessage(ctx, msg)
+		if err == nil {
+			return nil
+		}
+
+		var permanentErr *PermanentError
+		if errors.As(err, &permanentErr) || (attempt >= maxProcessAttempts && c.deadLetter != nil) {
+			return err
+		}
+
+		c.logger.Printf("Failed to process message (attempt %d), retrying in %v: %v", attempt, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
 func (c *KafkaConsumer) processMessage(ctx context.Context, msg kafka.Message) error {
 	var request events.DomainEvent
 
 	if err := json.Unmarshal(msg.Value, &request); err != nil {
-		return fmt.Errorf("failed to unmarshal message: %w", err)
+		return permanent(fmt.Errorf("failed to unmarshal message: %w", err))
 	}
 
 	c.logger.Printf("Processing event: %s ---> Type %s", request.ID, request.EventType)
 
 	// Validate
 	if err := request.Validate(); err != nil {
-		return fmt.Errorf("failed to validate request: %w", err)
+		return permanent(fmt.Errorf("failed to validate request: %w", err))
 	}
 
 	return c.handler.HandleMessage(ctx, request)
 }
 
+// sendToDeadLetter publishes msg to the dead letter topic with the error and
+// where it came from in its headers. Without a dead letter topic the message,
+// which then failed permanently, is dropped.
+func (c *KafkaConsumer) sendToDeadLetter(ctx context.Context, msg kafka.Message, cause error) error {
+	if c.deadLetter == nil {
+		c.logger.Printf("Dropping message at offset %d, no dead letter topic: %v", msg.Offset, cause)
+		return nil
+	}
+
+	headers := append([]kafka.Header{}, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: "x-error", Value: []byte(cause.Error())},
+		kafka.Header{Key: "x-original-topic", Value: []byte(msg.Topic)},
+		kafka.Header{Key: "x-original-partition", Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: "x-original-offset", Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+	)
+
+	err := c.deadLetter.WriteMessages(ctx, kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	})
+	if err != nil {
+		c.logger.Printf("Failed to dead-letter message at offset %d: %v (cause: %v)", msg.Offset, err, cause)
+		return err
+	}
+
+	c.logger.Printf("Dead-lettered message at offset %d: %v", msg.Offset, cause)
+	return nil
+}
+
 func (c *KafkaConsumer) Close() error {
 	c.logger.Println("Closing Kafka consumer")
+	if c.deadLetter != nil {
+		if err := c.deadLetter.Close(); err != nil {
+			c.logger.Printf("Failed to close dead letter writer: %v", err)
+		}
+	}
 	return c.reader.Close()
 }
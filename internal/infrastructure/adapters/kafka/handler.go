@@ -3,27 +3,38 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 
 	"github.com/commitshark/notification-svc/internal/application/services"
-	"github.com/commitshark/notification-svc/internal/domain"
 	"github.com/commitshark/notification-svc/internal/domain/events"
-	"github.com/commitshark/notification-svc/internal/domain/ports"
 )
 
+// PermanentError marks a message that fails the same way however often it is
+// processed; it is dead-lettered instead of retried
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+func permanent(err error) error {
+	return &PermanentError{Err: err}
+}
+
 // KafkaMessageHandler adapts Kafka messages to application service
 type KafkaMessageHandler struct {
-	service        *services.NotificationService
-	userDataSource ports.UserDataAdapter
-	logger         *log.Logger
+	ingestion *services.IngestionService
+	logger    *log.Logger
 }
 
-func NewKafkaMessageHandler(service *services.NotificationService, userAdapter ports.UserDataAdapter) *KafkaMessageHandler {
+func NewKafkaMessageHandler(ingestion *services.IngestionService) *KafkaMessageHandler {
 	return &KafkaMessageHandler{
-		service:        service,
-		userDataSource: userAdapter,
-		logger:         log.New(log.Writer(), "[KafkaHandler] ", log.LstdFlags),
+		ingestion: ingestion,
+		logger:    log.New(log.Writer(), "[KafkaHandler] ", log.LstdFlags),
 	}
 }
 
@@ -32,49 +43,22 @@ func NewKafkaMessageHandler(service *services.NotificationService, userAdapter p
 func (h *KafkaMessageHandler) HandleMessage(ctx context.Context, ev events.DomainEvent) error {
 	var payload events.NotificationMessagePayload
 	if err := json.Unmarshal(ev.Payload, &payload); err != nil {
-		return fmt.Errorf("notification[%s]: invalid payload: %w", ev.ID, err)
+		return permanent(fmt.Errorf("notification[%s]: invalid payload: %w", ev.ID, err))
 	}
 
-	user, err := h.userDataSource.GetContactInfo(ctx, payload.UserID)
-	if err != nil {
-		return fmt.Errorf("notification[%s]: getContactInfo failed: %w, user: %s", ev.ID, err, payload.UserID)
+	// Process through application service. Undeliverable recipients are
+	// recorded as rejected and don't return an error.
+	result, err := h.ingestion.Ingest(ctx, ev.ID, payload)
+	if errors.Is(err, services.ErrInvalidPayload) || errors.Is(err, services.ErrIdempotencyKeyReused) {
+		return permanent(err)
 	}
-
-	// Convert to domain objects
-	recipient, err := domain.NewRecipient(
-		payload.UserID,
-		&user.Email,
-		user.Phone,
-		user.DeviceID,
-	)
-	if err != nil {
-		return fmt.Errorf("notification[%s]: invalid content: %w", ev.ID, err)
-	}
-
-	content, err := domain.NewContent(
-		payload.Subject,
-		payload.Message,
-		payload.Data,
-		payload.HTML,
-		payload.Template,
-	)
 	if err != nil {
-		return fmt.Errorf("notification[%s]: invalid content: %w", ev.ID, err)
+		return err
 	}
 
-	isShell := 0
-	if payload.Type == "shell" {
-		isShell = 1
+	if result.Duplicate {
+		h.logger.Printf("notification[%s]: already ingested, skipping", ev.ID)
 	}
 
-	// Process through application service
-	return h.service.ProcessNotification(
-		ctx,
-		ev.ID,
-		domain.NotificationType(payload.Channel),
-		*recipient,
-		*content,
-		3,
-		isShell,
-	)
+	return nil
 }
@@ -0,0 +1,183 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	config "github.com/commitshark/notification-svc/internal"
+	"github.com/commitshark/notification-svc/internal/domain/events"
+	"github.com/segmentio/kafka-go"
+)
+
+// Invalidator drops cached data about a user
+type Invalidator interface {
+	Invalidate(userID string)
+}
+
+// UserEventsConsumer invalidates cached user data when the user service
+// publishes a change. Every instance has to see every event, so it reads each
+// partition directly, without a consumer group, starting from the newest
+// offset. Events published while the instance is down are never seen; the
+// cache starts empty then, and entries cached afterwards can only be stale
+// for as long as the cache TTL.
+type UserEventsConsumer struct {
+	brokers     []string
+	topic       string
+	invalidator Invalidator
+	logger      *log.Logger
+
+	mu      sync.Mutex
+	readers []*kafka.Reader
+	closed  bool
+}
+
+func NewUserEventsConsumer(kConfig config.KafkaConfig, invalidator Invalidator) *UserEventsConsumer {
+	return &UserEventsConsumer{
+		brokers:     kConfig.Brokers,
+		topic:       kConfig.UserEventsTopic,
+		invalidator: invalidator,
+		logger:      log.New(os.Stdout, "[UserEventsConsumer] ", log.LstdFlags),
+	}
+}
+
+func (c *UserEventsConsumer) Start(ctx context.Context) error {
+	c.logger.Printf("Starting user events consumer for topic: %s", c.topic)
+
+	partitions, err := c.partitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	readers, err := c.open(partitions)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, reader := range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.consume(ctx, reader)
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// partitions lists the topic's partitions from the first broker that answers
+func (c *UserEventsConsumer) partitions(ctx context.Context) ([]kafka.Partition, error) {
+	var lastErr error
+	for _, broker := range c.brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		partitions, err := conn.ReadPartitions(c.topic)
+		conn.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return partitions, nil
+	}
+
+	return nil, fmt.Errorf("failed to read partitions of %s: %w", c.topic, lastErr)
+}
+
+// open creates a reader per partition, unless the consumer is already closed
+func (c *UserEventsConsumer) open(partitions []kafka.Partition) ([]*kafka.Reader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, errors.New("user events consumer is closed")
+	}
+
+	for _, p := range partitions {
+		c.readers = append(c.readers, kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     c.brokers,
+			Topic:       c.topic,
+			Partition:   p.ID,
+			StartOffset: kafka.LastOffset,
+			MinBytes:    1,
+			MaxBytes:    1e6, // 1MB
+			ErrorLogger: kafka.LoggerFunc(c.logger.Printf),
+		}))
+	}
+	return c.readers, nil
+}
+
+func (c *UserEventsConsumer) consume(ctx context.Context, reader *kafka.Reader) {
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, io.EOF) {
+				return
+			}
+			c.logger.Printf("Error reading message: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		userID, err := userIDFromEvent(msg.Value)
+		if err != nil {
+			c.logger.Printf("Skipping message at partition %d offset %d: %v", msg.Partition, msg.Offset, err)
+			continue
+		}
+
+		c.invalidator.Invalidate(userID)
+	}
+}
+
+// userIDFromEvent reads the user an event is about: its aggregate ID, or the
+// payload's user_id
+func userIDFromEvent(value []byte) (string, error) {
+	var ev events.DomainEvent
+	if err := json.Unmarshal(value, &ev); err != nil {
+		return "", fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	if ev.AggID != "" {
+		return ev.AggID, nil
+	}
+
+	var payload struct {
+		UserID string `json:"user_id"`
+	}
+	if len(ev.Payload) > 0 {
+		if err := json.Unmarshal(ev.Payload, &payload); err != nil {
+			return "", fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+	}
+
+	if payload.UserID == "" {
+		return "", fmt.Errorf("event %s (%s) names no user", ev.ID, ev.EventType)
+	}
+	return payload.UserID, nil
+}
+
+func (c *UserEventsConsumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+
+	var errs []error
+	for _, reader := range c.readers {
+		if err := reader.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
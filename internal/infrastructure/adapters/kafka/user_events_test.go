@@ -0,0 +1,33 @@
+package kafka
+
+import "testing"
+
+func TestUserIDFromEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string // empty when the event is skipped
+	}{
+		{"aggregate id", `{"event_id":"e1","aggregate_id":"u1","event_type":"user.updated","payload":{"user_id":"u2"}}`, "u1"},
+		{"payload user id", `{"event_id":"e1","event_type":"user.updated","payload":{"user_id":"u2"}}`, "u2"},
+		{"no user", `{"event_id":"e1","event_type":"user.updated","payload":{}}`, ""},
+		{"no payload", `{"event_id":"e1","event_type":"user.updated"}`, ""},
+		{"bad payload", `{"event_id":"e1","event_type":"user.updated","payload":"u1"}`, ""},
+		{"not json", `user u1 changed`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := userIDFromEvent([]byte(tt.value))
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("userIDFromEvent = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("userIDFromEvent = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
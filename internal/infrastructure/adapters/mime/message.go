@@ -0,0 +1,331 @@
+package mimemessage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Header is a single extra header supplied by a template or provider.
+type Header struct {
+	Name  string
+	Value string
+}
+
+// Attachment is a file carried by the message. Inline attachments are placed
+// in a multipart/related part and referenced from the HTML via cid:<ContentID>.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	ContentID   string
+	Inline      bool
+	Data        []byte
+}
+
+// Message is everything needed to compose an RFC 5322 email.
+type Message struct {
+	From        mail.Address
+	To          []mail.Address
+	ReplyTo     *mail.Address
+	Subject     string
+	Date        time.Time
+	MessageID   string
+	Headers     []Header
+	Text        string
+	HTML        string
+	Attachments []Attachment
+}
+
+// Reserved headers are produced by the builder itself and cannot be overridden.
+var reservedHeaders = map[string]bool{
+	"From":                      true,
+	"To":                        true,
+	"Reply-To":                  true,
+	"Subject":                   true,
+	"Date":                      true,
+	"Message-Id":                true,
+	"Mime-Version":              true,
+	"Content-Type":              true,
+	"Content-Transfer-Encoding": true,
+}
+
+// HeadersFromMap turns a template header map into a deterministic header list.
+func HeadersFromMap(m map[string]string) []Header {
+	headers := make([]Header, 0, len(m))
+	for k, v := range m {
+		headers = append(headers, Header{Name: k, Value: v})
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+	return headers
+}
+
+// ParseAddress parses "Name <email>" or a bare email address.
+func ParseAddress(s string) (mail.Address, error) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return mail.Address{}, fmt.Errorf("invalid address %q: %w", s, err)
+	}
+	return *addr, nil
+}
+
+// Bytes builds the wire representation of the message.
+func (m *Message) Bytes() ([]byte, error) {
+	if m.From.Address == "" {
+		return nil, fmt.Errorf("message has no sender")
+	}
+	if len(m.To) == 0 {
+		return nil, fmt.Errorf("message has no recipients")
+	}
+	if m.Text == "" && m.HTML == "" {
+		return nil, fmt.Errorf("message has no body")
+	}
+
+	date := m.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	messageID := m.MessageID
+	if messageID == "" {
+		messageID = NewMessageID(m.From.Address)
+	}
+
+	var buf bytes.Buffer
+
+	to := make([]string, 0, len(m.To))
+	for _, a := range m.To {
+		to = append(to, a.String())
+	}
+
+	headers := []Header{
+		{"From", m.From.String()},
+		{"To", strings.Join(to, ", ")},
+	}
+	if m.ReplyTo != nil {
+		headers = append(headers, Header{"Reply-To", m.ReplyTo.String()})
+	}
+	headers = append(headers,
+		Header{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		Header{"Date", date.Format(time.RFC1123Z)},
+		Header{"Message-ID", messageID},
+		Header{"MIME-Version", "1.0"},
+	)
+
+	for _, h := range m.Headers {
+		name := textproto.CanonicalMIMEHeaderKey(h.Name)
+		if reservedHeaders[name] {
+			return nil, fmt.Errorf("header %s cannot be overridden", h.Name)
+		}
+		headers = append(headers, Header{h.Name, h.Value})
+	}
+
+	for _, h := range headers {
+		if err := writeHeader(&buf, h.Name, h.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.writeBody(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (m *Message) writeBody(buf *bytes.Buffer) error {
+	var inline, attached []Attachment
+	for _, a := range m.Attachments {
+		if a.Inline {
+			inline = append(inline, a)
+		} else {
+			attached = append(attached, a)
+		}
+	}
+
+	// Build innermost first: alternative -> related -> mixed.
+	body := m.alternativePart()
+
+	if len(inline) > 0 {
+		parts := []part{body}
+		for _, a := range inline {
+			parts = append(parts, attachmentPart(a))
+		}
+		body = multipart("multipart/related", parts)
+	}
+
+	if len(attached) > 0 {
+		parts := []part{body}
+		for _, a := range attached {
+			parts = append(parts, attachmentPart(a))
+		}
+		body = multipart("multipart/mixed", parts)
+	}
+
+	return body.writeTo(buf)
+}
+
+func (m *Message) alternativePart() part {
+	var parts []part
+	if m.Text != "" {
+		parts = append(parts, textPart("text/plain", m.Text))
+	}
+	if m.HTML != "" {
+		parts = append(parts, textPart("text/html", m.HTML))
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return multipart("multipart/alternative", parts)
+}
+
+// part is a MIME entity: its headers plus a body writer.
+type part struct {
+	headers []Header
+	write   func(buf *bytes.Buffer) error
+}
+
+func (p part) writeTo(buf *bytes.Buffer) error {
+	for _, h := range p.headers {
+		if err := writeHeader(buf, h.Name, h.Value); err != nil {
+			return err
+		}
+	}
+	buf.WriteString("\r\n")
+	return p.write(buf)
+}
+
+func textPart(contentType, content string) part {
+	return part{
+		headers: []Header{
+			{"Content-Type", contentType + "; charset=\"UTF-8\""},
+			{"Content-Transfer-Encoding", "quoted-printable"},
+		},
+		write: func(buf *bytes.Buffer) error {
+			w := quotedprintable.NewWriter(buf)
+			if _, err := w.Write([]byte(content)); err != nil {
+				return err
+			}
+			if err := w.Close(); err != nil {
+				return err
+			}
+			buf.WriteString("\r\n")
+			return nil
+		},
+	}
+}
+
+func attachmentPart(a Attachment) part {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	disposition := "attachment"
+	if a.Inline {
+		disposition = "inline"
+	}
+
+	headers := []Header{
+		{"Content-Type", contentType},
+		{"Content-Transfer-Encoding", "base64"},
+	}
+	if a.Filename != "" {
+		headers[0].Value = withParam(contentType, "name", a.Filename)
+		headers = append(headers, Header{"Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename})})
+	} else {
+		headers = append(headers, Header{"Content-Disposition", disposition})
+	}
+	if a.ContentID != "" {
+		headers = append(headers, Header{"Content-ID", "<" + strings.Trim(a.ContentID, "<>") + ">"})
+	}
+
+	return part{
+		headers: headers,
+		write: func(buf *bytes.Buffer) error {
+			buf.WriteString(wrap(base64.StdEncoding.EncodeToString(a.Data), 76))
+			return nil
+		},
+	}
+}
+
+// withParam adds a parameter to a media type that may already carry parameters.
+func withParam(contentType, key, value string) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	params[key] = value
+	if v := mime.FormatMediaType(mediaType, params); v != "" {
+		return v
+	}
+	return contentType
+}
+
+func multipart(contentType string, parts []part) part {
+	boundary := newBoundary()
+	return part{
+		headers: []Header{
+			{"Content-Type", mime.FormatMediaType(contentType, map[string]string{"boundary": boundary})},
+		},
+		write: func(buf *bytes.Buffer) error {
+			for _, p := range parts {
+				buf.WriteString("--" + boundary + "\r\n")
+				if err := p.writeTo(buf); err != nil {
+					return err
+				}
+			}
+			buf.WriteString("--" + boundary + "--\r\n")
+			return nil
+		},
+	}
+}
+
+func writeHeader(buf *bytes.Buffer, name, value string) error {
+	if strings.ContainsAny(name, "\r\n: ") || name == "" {
+		return fmt.Errorf("invalid header name %q", name)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("header %s contains line breaks", name)
+	}
+	buf.WriteString(name + ": " + value + "\r\n")
+	return nil
+}
+
+func wrap(s string, lineLen int) string {
+	var b strings.Builder
+	for len(s) > 0 {
+		chunk := min(lineLen, len(s))
+		b.WriteString(s[:chunk])
+		b.WriteString("\r\n")
+		s = s[chunk:]
+	}
+	return b.String()
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func newBoundary() string {
+	return "=_" + randomHex(16)
+}
+
+// NewMessageID generates a Message-ID in the sender's domain.
+func NewMessageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at != -1 && at < len(from)-1 {
+		domain = from[at+1:]
+	}
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), randomHex(8), domain)
+}
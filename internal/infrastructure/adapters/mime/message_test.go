@@ -0,0 +1,196 @@
+package mimemessage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	multipartreader "mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessageBytes(t *testing.T) {
+	base := func() Message {
+		return Message{
+			From:      mail.Address{Name: "Events", Address: "events@example.com"},
+			To:        []mail.Address{{Address: "a@example.com"}},
+			Subject:   "Your ticket – Café",
+			Date:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			MessageID: "<1@example.com>",
+			Text:      "Hello",
+			HTML:      "<p>Hello</p>",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(m *Message)
+		want    string // top-level media type
+		parts   []string
+		wantErr string
+	}{
+		{"text only", func(m *Message) { m.HTML = "" }, "text/plain", nil, ""},
+		{"html only", func(m *Message) { m.Text = "" }, "text/html", nil, ""},
+		{"alternative", func(m *Message) {}, "multipart/alternative", []string{"text/plain", "text/html"}, ""},
+		{"inline image", func(m *Message) {
+			m.Attachments = []Attachment{{Filename: "logo.png", ContentType: "image/png", ContentID: "logo", Inline: true, Data: []byte("png")}}
+		}, "multipart/related", []string{"multipart/alternative", "image/png"}, ""},
+		{"attachment", func(m *Message) {
+			m.Attachments = []Attachment{{Filename: "invite.ics", ContentType: "text/calendar; method=REQUEST", Data: []byte("ics")}}
+		}, "multipart/mixed", []string{"multipart/alternative", "text/calendar"}, ""},
+		{"inline and attached", func(m *Message) {
+			m.Attachments = []Attachment{
+				{Filename: "logo.png", ContentType: "image/png", ContentID: "logo", Inline: true, Data: []byte("png")},
+				{Filename: "ticket.pdf", Data: []byte("pdf")},
+			}
+		}, "multipart/mixed", []string{"multipart/related", "application/octet-stream"}, ""},
+		{"no sender", func(m *Message) { m.From = mail.Address{} }, "", nil, "no sender"},
+		{"no recipients", func(m *Message) { m.To = nil }, "", nil, "no recipients"},
+		{"no body", func(m *Message) { m.Text, m.HTML = "", "" }, "", nil, "no body"},
+		{"reserved header", func(m *Message) { m.Headers = []Header{{"subject", "x"}} }, "", nil, "cannot be overridden"},
+		{"header injection", func(m *Message) { m.Headers = []Header{{"X-Tag", "a\r\nBcc: b@example.com"}} }, "", nil, "line breaks"},
+		{"bad header name", func(m *Message) { m.Headers = []Header{{"X Tag", "a"}} }, "", nil, "invalid header name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := base()
+			tt.edit(&m)
+
+			raw, err := m.Bytes()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Bytes: err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Bytes: %v", err)
+			}
+
+			msg, err := mail.ReadMessage(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatalf("ReadMessage: %v", err)
+			}
+
+			subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			if err != nil || subject != m.Subject {
+				t.Errorf("Subject = %q, %v", subject, err)
+			}
+			if got := msg.Header.Get("Message-ID"); got != "<1@example.com>" {
+				t.Errorf("Message-ID = %q", got)
+			}
+			if got := msg.Header.Get("MIME-Version"); got != "1.0" {
+				t.Errorf("MIME-Version = %q", got)
+			}
+
+			mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+			if err != nil {
+				t.Fatalf("Content-Type: %v", err)
+			}
+			if mediaType != tt.want {
+				t.Fatalf("Content-Type = %s, want %s", mediaType, tt.want)
+			}
+			if tt.parts == nil {
+				return
+			}
+
+			var got []string
+			r := multipartreader.NewReader(msg.Body, params["boundary"])
+			for {
+				p, err := r.NextPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("NextPart: %v", err)
+				}
+				partType, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+				got = append(got, partType)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.parts, ",") {
+				t.Errorf("parts = %v, want %v", got, tt.parts)
+			}
+		})
+	}
+}
+
+func TestMessageBodyEncoding(t *testing.T) {
+	long := strings.Repeat("é", 100)
+	data := bytes.Repeat([]byte{0xff, 0x00, 0x7f}, 100)
+
+	m := Message{
+		From: mail.Address{Address: "events@example.com"},
+		To:   []mail.Address{{Address: "a@example.com"}},
+		Text: long,
+		Attachments: []Attachment{
+			{Filename: "billet été.pdf", ContentType: "application/pdf", Data: data},
+		},
+	}
+
+	raw, err := m.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+
+	for _, line := range strings.Split(string(raw), "\r\n") {
+		if len(line) > 78 {
+			t.Fatalf("line of %d characters: %q", len(line), line)
+		}
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	_, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	r := multipartreader.NewReader(msg.Body, params["boundary"])
+
+	text, err := r.NextPart()
+	if err != nil {
+		t.Fatalf("NextPart: %v", err)
+	}
+	// multipart.Reader decodes quoted-printable itself
+	body, _ := io.ReadAll(text)
+	if strings.TrimRight(string(body), "\r\n") != long {
+		t.Errorf("text = %q", body)
+	}
+
+	file, err := r.NextPart()
+	if err != nil {
+		t.Fatalf("NextPart: %v", err)
+	}
+	if file.FileName() != "billet été.pdf" {
+		t.Errorf("filename = %q", file.FileName())
+	}
+	encoded, _ := io.ReadAll(file)
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	if err != nil || !bytes.Equal(decoded, data) {
+		t.Errorf("attachment = %x, %v", decoded, err)
+	}
+}
+
+func TestNewMessageID(t *testing.T) {
+	tests := []struct {
+		from string
+		want string
+	}{
+		{"events@example.com", "@example.com>"},
+		{"events", "@localhost>"},
+		{"events@", "@localhost>"},
+	}
+
+	for _, tt := range tests {
+		id := NewMessageID(tt.from)
+		if !strings.HasPrefix(id, "<") || !strings.HasSuffix(id, tt.want) {
+			t.Errorf("NewMessageID(%q) = %q, want suffix %q", tt.from, id, tt.want)
+		}
+	}
+
+	if NewMessageID("a@example.com") == NewMessageID("a@example.com") {
+		t.Error("NewMessageID repeated an ID")
+	}
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+)
+
+type PostgresCampaignRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCampaignRepository(dsn string) (ports.CampaignRepository, error) {
+	db, err := open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresCampaignRepository{db: db}, nil
+}
+
+const campaignColumns = `id, name, channel, template, subject, locale, data, audience, rate_per_second,
+	status, scheduled_at, cursor, created_at, updated_at, started_at, completed_at, version`
+
+func scanCampaign(row rowScanner) (*domain.Campaign, error) {
+	var c domain.Campaign
+	var channel, status, audienceJSON string
+	var locale, dataJSON sql.NullString
+	var scheduledAt, startedAt, completedAt sql.NullTime
+
+	err := row.Scan(
+		&c.ID, &c.Name, &channel, &c.Template, &c.Subject, &locale, &dataJSON, &audienceJSON, &c.RatePerSecond,
+		&status, &scheduledAt, &c.Cursor, &c.CreatedAt, &c.UpdatedAt, &startedAt, &completedAt, &c.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Channel = domain.NotificationType(channel)
+	c.Status = domain.CampaignStatus(status)
+	c.Locale = locale.String
+	c.ScheduledAt = nullableTime(scheduledAt)
+	c.StartedAt = nullableTime(startedAt)
+	c.CompletedAt = nullableTime(completedAt)
+
+	if dataJSON.Valid && dataJSON.String != "" {
+		if err := json.Unmarshal([]byte(dataJSON.String), &c.Data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal campaign data: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(audienceJSON), &c.Audience); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal campaign audience: %w", err)
+	}
+
+	return &c, nil
+}
+
+// Save is an upsert with optimistic locking: an update only applies when the
+// stored version is the one c was loaded with
+func (r *PostgresCampaignRepository) Save(ctx context.Context, c *domain.Campaign) error {
+	dataJSON, err := json.Marshal(c.Data)
+	if err != nil {
+		return err
+	}
+
+	audienceJSON, err := json.Marshal(c.Audience)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+	INSERT INTO campaigns (`+campaignColumns+`)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	ON CONFLICT (id) DO UPDATE SET
+		status = excluded.status,
+		scheduled_at = excluded.scheduled_at,
+		cursor = excluded.cursor,
+		updated_at = excluded.updated_at,
+		started_at = excluded.started_at,
+		completed_at = excluded.completed_at,
+		version = excluded.version
+	WHERE campaigns.version = $18
+	`,
+		c.ID, c.Name, string(c.Channel), c.Template, c.Subject, c.Locale, string(dataJSON), string(audienceJSON), c.RatePerSecond,
+		string(c.Status), c.ScheduledAt, c.Cursor, c.CreatedAt, c.UpdatedAt, c.StartedAt, c.CompletedAt, c.Version,
+		c.Version-1,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save campaign: %w", err)
+	}
+
+	return requireAffected(result, fmt.Errorf("%w: %s", domain.ErrCampaignConflict, c.ID))
+}
+
+func (r *PostgresCampaignRepository) FindByID(ctx context.Context, id string) (*domain.Campaign, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+campaignColumns+` FROM campaigns WHERE id = $1`, id)
+
+	c, err := scanCampaign(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrCampaignNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find campaign: %w", err)
+	}
+	return c, nil
+}
+
+func (r *PostgresCampaignRepository) List(ctx context.Context) ([]*domain.Campaign, error) {
+	return r.query(ctx, `SELECT `+campaignColumns+` FROM campaigns ORDER BY created_at DESC`)
+}
+
+func (r *PostgresCampaignRepository) FindActive(ctx context.Context) ([]*domain.Campaign, error) {
+	return r.query(ctx, `SELECT `+campaignColumns+` FROM campaigns WHERE status IN ('SCHEDULED', 'SENDING') ORDER BY created_at ASC`)
+}
+
+func (r *PostgresCampaignRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Campaign, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	var campaigns []*domain.Campaign
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, c)
+	}
+
+	return campaigns, rows.Err()
+}
+
+func (r *PostgresCampaignRepository) Close() error {
+	return r.db.Close()
+}
+
+func requireAffected(result sql.Result, notAffected error) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return notAffected
+	}
+	return nil
+}
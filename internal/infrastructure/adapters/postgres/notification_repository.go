@@ -0,0 +1,846 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/encryption"
+	"github.com/commitshark/notification-svc/internal/utils"
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// schemaLockID serializes schema creation between replicas starting together
+const schemaLockID = 7210431
+
+// PostgresNotificationRepository encrypts recipient contact details and message
+// content with keyring; a nil keyring stores them in plaintext.
+type PostgresNotificationRepository struct {
+	db      *sql.DB
+	keyring *encryption.Keyring
+}
+
+func NewPostgresNotificationRepository(dsn string, keyring *encryption.Keyring) (ports.NotificationRepository, error) {
+	db, err := open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresNotificationRepository{db: db, keyring: keyring}, nil
+}
+
+// open connects to the database at dsn and creates the schema if needed
+func open(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Postgres database: %w", err)
+	}
+
+	db.SetMaxOpenConns(25)
+	db.SetMaxIdleConns(5)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to Postgres: %w", err)
+	}
+
+	if err := createTables(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+
+	return db, nil
+}
+
+func createTables(db *sql.DB) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS notifications (
+			id TEXT PRIMARY KEY,
+			type TEXT NOT NULL,
+			recipient_id TEXT NOT NULL,
+			recipient_email TEXT,
+			recipient_phone TEXT,
+			recipient_device TEXT,
+			recipient_spec TEXT,
+			title TEXT NOT NULL,
+			body TEXT,
+			data TEXT, -- JSON data
+			html TEXT,
+			template TEXT,
+			locale TEXT,
+			attachments TEXT, -- JSON array
+			status TEXT NOT NULL,
+			provider_response TEXT,
+			created_at TIMESTAMPTZ NOT NULL,
+			sent_at TIMESTAMPTZ,
+			next_attempt_at TIMESTAMPTZ,
+			retry_count INTEGER NOT NULL DEFAULT 0,
+			max_retries INTEGER NOT NULL DEFAULT 3,
+			version INTEGER NOT NULL DEFAULT 1,
+			is_marketing INTEGER NOT NULL DEFAULT 0,
+			template_version INTEGER NOT NULL DEFAULT 0,
+			resent_from TEXT,
+			campaign_id TEXT,
+			CHECK (type IN ('EMAIL', 'SMS', 'PUSH', 'IN_APP')),
+			CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'DELIVERED', 'CANCELLED', 'REJECTED'))
+		)`,
+		"CREATE INDEX IF NOT EXISTS idx_notifications_status ON notifications(status, created_at)",
+		"CREATE INDEX IF NOT EXISTS idx_notifications_recipient ON notifications(recipient_id)",
+		"CREATE INDEX IF NOT EXISTS idx_notifications_campaign ON notifications(campaign_id, status) WHERE campaign_id IS NOT NULL",
+		"CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(next_attempt_at) WHERE status IN ('PENDING', 'FAILED')",
+		// Field-level encryption
+		"ALTER TABLE notifications ADD COLUMN IF NOT EXISTS key_id TEXT",
+		"ALTER TABLE notifications ADD COLUMN IF NOT EXISTS recipient_email_bidx TEXT",
+		"ALTER TABLE notifications ADD COLUMN IF NOT EXISTS recipient_phone_bidx TEXT",
+		// Rows encrypted before title and attachments were have those in plaintext
+		"ALTER TABLE notifications ADD COLUMN IF NOT EXISTS content_sealed BOOLEAN NOT NULL DEFAULT FALSE",
+		"ALTER TABLE notifications ADD COLUMN IF NOT EXISTS request_hash TEXT",
+		"CREATE INDEX IF NOT EXISTS idx_notifications_email_bidx ON notifications(recipient_email_bidx)",
+		"CREATE INDEX IF NOT EXISTS idx_notifications_phone_bidx ON notifications(recipient_phone_bidx)",
+		`CREATE TABLE IF NOT EXISTS campaigns (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			channel TEXT NOT NULL,
+			template TEXT NOT NULL,
+			subject TEXT NOT NULL,
+			locale TEXT,
+			data TEXT, -- JSON object
+			audience TEXT NOT NULL, -- JSON object
+			rate_per_second INTEGER NOT NULL,
+			status TEXT NOT NULL,
+			scheduled_at TIMESTAMPTZ,
+			cursor INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			started_at TIMESTAMPTZ,
+			completed_at TIMESTAMPTZ,
+			version INTEGER NOT NULL,
+			CHECK (status IN ('DRAFT', 'SCHEDULED', 'SENDING', 'PAUSED', 'COMPLETED', 'CANCELLED'))
+		)`,
+		"CREATE INDEX IF NOT EXISTS idx_campaigns_status ON campaigns(status, created_at)",
+		`CREATE TABLE IF NOT EXISTS email_templates (
+			name TEXT NOT NULL,
+			version INTEGER NOT NULL,
+			body TEXT NOT NULL,
+			status TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			published_at TIMESTAMPTZ,
+			PRIMARY KEY (name, version),
+			CHECK (status IN ('DRAFT', 'PUBLISHED', 'ARCHIVED'))
+		)`,
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_email_templates_published ON email_templates(name) WHERE status = 'PUBLISHED'",
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, schemaLockID); err != nil {
+		return fmt.Errorf("failed to lock schema: %w", err)
+	}
+
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// notificationColumns is the column list scanNotification expects
+const notificationColumns = `
+	id,
+	type,
+	recipient_id,
+	recipient_email,
+	recipient_phone,
+	recipient_device,
+	title,
+	body,
+	data,
+	html,
+	template,
+	status,
+	provider_response,
+	created_at,
+	sent_at,
+	retry_count,
+	max_retries,
+	is_marketing,
+	version,
+	attachments,
+	locale,
+	template_version,
+	resent_from,
+	campaign_id,
+	recipient_spec,
+	next_attempt_at,
+	key_id,
+	content_sealed,
+	request_hash
+`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanNotification(rows rowScanner, keyring *encryption.Keyring) (*domain.Notification, error) {
+	var n domain.Notification
+	var recipientID string
+	var recipientEmail, recipientPhone, recipientDevice, html, template sql.NullString
+	var title, statusStr, typeStr string
+	var body, dataJSON, attachmentsJSON, locale sql.NullString
+	var providerResponse sql.NullString
+	var sentAt, nextAttemptAt sql.NullTime
+	var resentFrom, campaignID, recipientSpec, keyID sql.NullString
+	var contentSealed bool
+	var requestHash sql.NullString
+
+	err := rows.Scan(
+		&n.ID, &typeStr, &recipientID, &recipientEmail, &recipientPhone, &recipientDevice,
+		&title, &body, &dataJSON, &html, &template, &statusStr, &providerResponse,
+		&n.CreatedAt, &sentAt, &n.RetryCount, &n.MaxRetries, &n.IsMarketing, &n.Version,
+		&attachmentsJSON, &locale, &n.TemplateVersion, &resentFrom, &campaignID, &recipientSpec,
+		&nextAttemptAt, &keyID, &contentSealed, &requestHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	open := keyring.Open
+	if !contentSealed {
+		open = keyring.OpenLegacy
+	}
+
+	fields, err := open(n.ID, keyID.String, encryption.Fields{
+		Email:       utils.SqlNullableString(recipientEmail),
+		Phone:       utils.SqlNullableString(recipientPhone),
+		Device:      utils.SqlNullableString(recipientDevice),
+		Title:       &title,
+		Body:        utils.SqlNullableString(body),
+		Data:        utils.SqlNullableString(dataJSON),
+		HTML:        utils.SqlNullableString(html),
+		Attachments: utils.SqlNullableString(attachmentsJSON),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]interface{}
+	if fields.Data != nil && *fields.Data != "" {
+		if err := json.Unmarshal([]byte(*fields.Data), &data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal data: %w", err)
+		}
+	}
+
+	var attachments []domain.Attachment
+	if fields.Attachments != nil && *fields.Attachments != "" {
+		if err := json.Unmarshal([]byte(*fields.Attachments), &attachments); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal attachments: %w", err)
+		}
+	}
+
+	n.Type = domain.NotificationType(typeStr)
+	n.Status = domain.NotificationStatus(statusStr)
+	n.Recipient = domain.Recipient{
+		ID:       recipientID,
+		Email:    fields.Email,
+		Phone:    fields.Phone,
+		DeviceID: fields.Device,
+		Spec:     utils.SqlNullableString(recipientSpec),
+	}
+	n.Content = domain.Content{
+		Title:       *fields.Title,
+		Body:        fields.Body,
+		Data:        &data,
+		HTML:        fields.HTML,
+		Template:    utils.SqlNullableString(template),
+		Attachments: attachments,
+		Locale:      locale.String,
+	}
+	n.ProviderResponse = providerResponse.String
+	n.SentAt = nullableTime(sentAt)
+	n.NextAttemptAt = nullableTime(nextAttemptAt)
+	n.ResentFrom = utils.SqlNullableString(resentFrom)
+	n.CampaignID = utils.SqlNullableString(campaignID)
+	n.RequestHash = utils.SqlNullableString(requestHash)
+
+	return &n, nil
+}
+
+func nullableTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func (r *PostgresNotificationRepository) Save(ctx context.Context, notification *domain.Notification) error {
+	query := `
+INSERT INTO notifications (
+    id, type, recipient_id, recipient_email, recipient_phone,
+    recipient_device, title, body, data, status, provider_response,
+    created_at, sent_at, retry_count, max_retries, html, template, is_marketing, version,
+    attachments, locale, template_version, resent_from, campaign_id,
+    recipient_spec, next_attempt_at, key_id, recipient_email_bidx, recipient_phone_bidx,
+    content_sealed, request_hash
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31)
+ON CONFLICT (id) DO UPDATE SET
+    status = excluded.status,
+    provider_response = excluded.provider_response,
+    sent_at = excluded.sent_at,
+    retry_count = excluded.retry_count,
+    template_version = excluded.template_version,
+    next_attempt_at = excluded.next_attempt_at,
+    version = notifications.version + 1
+WHERE notifications.version = $32
+`
+
+	var dataJSON string
+	if notification.Content.Data != nil {
+		b, err := json.Marshal(notification.Content.Data)
+		if err != nil {
+			return err
+		}
+		dataJSON = string(b)
+	}
+
+	var attachmentsJSON *string
+	if len(notification.Content.Attachments) > 0 {
+		b, err := json.Marshal(notification.Content.Attachments)
+		if err != nil {
+			return err
+		}
+		encoded := string(b)
+		attachmentsJSON = &encoded
+	}
+
+	sealed, err := r.keyring.Seal(notification.ID, encryption.Fields{
+		Email:       notification.Recipient.Email,
+		Phone:       notification.Recipient.Phone,
+		Device:      notification.Recipient.DeviceID,
+		Title:       &notification.Content.Title,
+		Body:        notification.Content.Body,
+		Data:        &dataJSON,
+		HTML:        notification.Content.HTML,
+		Attachments: attachmentsJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encrypt notification: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		notification.ID,
+		string(notification.Type),
+		notification.Recipient.ID,
+		sealed.Email,
+		sealed.Phone,
+		sealed.Device,
+		sealed.Title,
+		sealed.Body,
+		sealed.Data,
+		string(notification.Status),
+		notification.ProviderResponse,
+		notification.CreatedAt,
+		notification.SentAt,
+		notification.RetryCount,
+		notification.MaxRetries,
+		sealed.HTML,
+		notification.Content.Template,
+		notification.IsMarketing,
+		notification.Version,
+		sealed.Attachments,
+		notification.Content.Locale,
+		notification.TemplateVersion,
+		notification.ResentFrom,
+		notification.CampaignID,
+		notification.Recipient.Spec,
+		notification.NextAttemptAt,
+		sealed.KeyID,
+		sealed.EmailIndex,
+		sealed.PhoneIndex,
+		sealed.KeyID != nil,
+		notification.RequestHash,
+		notification.Version-1, // For optimistic locking
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save notification: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w for notification %s", domain.ErrNotificationConflict, notification.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgresNotificationRepository) FindByID(ctx context.Context, id string) (*domain.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE id = $1`
+
+	n, err := scanNotification(r.db.QueryRowContext(ctx, query, id), r.keyring)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", domain.ErrNotificationNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan notification: %w", err)
+	}
+
+	return n, nil
+}
+
+// dueCondition matches the pending and failed notifications whose next attempt is due
+const dueCondition = `
+	status IN ('PENDING', 'FAILED')
+	AND retry_count < max_retries
+	AND (next_attempt_at IS NULL OR next_attempt_at <= now())
+`
+
+func (r *PostgresNotificationRepository) FindPending(ctx context.Context, limit int) ([]*domain.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE ` + dueCondition + `
+	ORDER BY created_at ASC
+	LIMIT $1`
+
+	return r.queryNotifications(ctx, query, limit)
+}
+
+// ClaimPending leases the due notifications in one statement. Rows another
+// worker is claiming are skipped rather than waited for.
+func (r *PostgresNotificationRepository) ClaimPending(ctx context.Context, limit int, until time.Time) ([]*domain.Notification, error) {
+	query := `
+	UPDATE notifications
+	SET next_attempt_at = $1,
+		version = version + 1
+	WHERE id IN (
+		SELECT id FROM notifications
+		WHERE ` + dueCondition + `
+		ORDER BY created_at ASC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + notificationColumns
+
+	return r.queryNotifications(ctx, query, until, limit)
+}
+
+func (r *PostgresNotificationRepository) PaginatedList(ctx context.Context, page int, pageSize int, filter domain.NotificationFilter) ([]*domain.Notification, int, error) {
+	offset := (page - 1) * pageSize
+
+	whereClause, args := filterConditions(filter, r.keyring)
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notifications WHERE 1=1`+whereClause, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	if total == 0 {
+		return []*domain.Notification{}, 0, nil
+	}
+
+	dataQuery := `SELECT ` + notificationColumns + ` FROM notifications WHERE 1=1` + whereClause +
+		fmt.Sprintf(` ORDER BY created_at DESC LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, pageSize, offset)
+
+	notifications, err := r.queryNotifications(ctx, dataQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if notifications == nil {
+		notifications = []*domain.Notification{}
+	}
+
+	return notifications, total, nil
+}
+
+// FindIDs returns the ids of every notification matching filter, oldest first
+func (r *PostgresNotificationRepository) FindIDs(ctx context.Context, filter domain.NotificationFilter) ([]string, error) {
+	whereClause, args := filterConditions(filter, r.keyring)
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM notifications WHERE 1=1`+whereClause+` ORDER BY created_at ASC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// CountByStatus counts the notifications matching filter per status
+func (r *PostgresNotificationRepository) CountByStatus(ctx context.Context, filter domain.NotificationFilter) (map[domain.NotificationStatus]int, error) {
+	whereClause, args := filterConditions(filter, r.keyring)
+
+	rows, err := r.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM notifications WHERE 1=1`+whereClause+` GROUP BY status`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[domain.NotificationStatus]int{}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[domain.NotificationStatus(status)] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// filterConditions builds the " AND ..." clause shared by listing and bulk
+// operations, numbering its placeholders from $1. Encrypted emails and phones
+// are matched through their blind indexes, rows still in plaintext through the
+// columns themselves.
+func filterConditions(filter domain.NotificationFilter, keyring *encryption.Keyring) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Status != nil && *filter.Status != "" {
+		conditions = append(conditions, "status = "+arg(string(*filter.Status)))
+	}
+
+	if filter.Type != nil && *filter.Type != "" {
+		conditions = append(conditions, "type = "+arg(string(*filter.Type)))
+	}
+
+	if filter.Query != "" {
+		q := arg("%" + strings.ToLower(filter.Query) + "%")
+		if keyring != nil {
+			conditions = append(conditions, fmt.Sprintf("((NOT content_sealed AND LOWER(title) LIKE %s) OR recipient_email_bidx = %s OR (key_id IS NULL AND LOWER(recipient_email) LIKE %s))",
+				q, arg(keyring.BlindIndex(filter.Query)), q))
+		} else {
+			conditions = append(conditions, "(LOWER(title) LIKE "+q+" OR LOWER(recipient_email) LIKE "+q+")")
+		}
+
+		// If it's a valid UUID, match exactly
+		if _, err := uuid.Parse(filter.Query); err == nil {
+			conditions = append(conditions, "recipient_id = "+arg(filter.Query))
+		}
+	}
+
+	if filter.IsMarketing != nil {
+		isMarketing := 0
+		if *filter.IsMarketing {
+			isMarketing = 1
+		}
+		conditions = append(conditions, "is_marketing = "+arg(isMarketing))
+	}
+
+	if filter.Template != nil && *filter.Template != "" {
+		conditions = append(conditions, "template = "+arg(*filter.Template))
+	}
+
+	if filter.Recipient != "" {
+		if keyring != nil {
+			index := arg(keyring.BlindIndex(filter.Recipient))
+			conditions = append(conditions, fmt.Sprintf(`(recipient_id = %s OR recipient_email_bidx = %s OR recipient_phone_bidx = %s
+				OR (key_id IS NULL AND (LOWER(recipient_email) = %s OR recipient_phone = %s)))`,
+				arg(filter.Recipient), index, index, arg(strings.ToLower(filter.Recipient)), arg(filter.Recipient)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("(recipient_id = %s OR LOWER(recipient_email) = %s OR recipient_phone = %s)",
+				arg(filter.Recipient), arg(strings.ToLower(filter.Recipient)), arg(filter.Recipient)))
+		}
+	}
+
+	if filter.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.CreatedAfter))
+	}
+
+	if filter.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.CreatedBefore))
+	}
+
+	if filter.CampaignID != nil {
+		conditions = append(conditions, "campaign_id = "+arg(*filter.CampaignID))
+	}
+
+	if filter.Settled {
+		conditions = append(conditions, "NOT (status = 'FAILED' AND retry_count < max_retries)")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " AND " + strings.Join(conditions, " AND "), args
+}
+
+func (r *PostgresNotificationRepository) UpdateStatus(
+	ctx context.Context,
+	id string,
+	status domain.NotificationStatus,
+	providerResponse string,
+) error {
+	currentVersion, err := r.currentVersion(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+	UPDATE notifications
+	SET status = $1,
+		provider_response = $2,
+		sent_at = CASE WHEN $1 = 'SENT' THEN now() ELSE sent_at END,
+		version = version + 1
+	WHERE id = $3 AND version = $4
+	`, string(status), providerResponse, id, currentVersion)
+	if err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return requireUpdated(result, id)
+}
+
+func (r *PostgresNotificationRepository) IncrementRetryCount(ctx context.Context, id string) error {
+	currentVersion, err := r.currentVersion(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+	UPDATE notifications
+	SET retry_count = retry_count + 1,
+		version = version + 1
+	WHERE id = $1 AND version = $2
+	`, id, currentVersion)
+	if err != nil {
+		return err
+	}
+
+	return requireUpdated(result, id)
+}
+
+func (r *PostgresNotificationRepository) DeleteByIDs(ctx context.Context, ids []string, filter domain.NotificationFilter, archive func([]*domain.Notification) error) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	whereClause, args := filterConditions(filter, r.keyring)
+	args = append(args, ids)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`DELETE FROM notifications WHERE id = ANY($%d)`, len(args))+whereClause+` RETURNING `+notificationColumns, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete notifications: %w", err)
+	}
+
+	var deleted []*domain.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows, r.keyring)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		deleted = append(deleted, n)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("failed to delete notifications: %w", err)
+	}
+	rows.Close()
+
+	if archive != nil && len(deleted) > 0 {
+		if err := archive(deleted); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to delete notifications: %w", err)
+	}
+
+	return len(deleted), nil
+}
+
+// Compact leaves reclaiming space to autovacuum
+func (r *PostgresNotificationRepository) Compact(ctx context.Context) error {
+	return nil
+}
+
+// Reencrypt moves up to limit rows after afterID stored in plaintext or under
+// an older key to the current key. Rows are locked while they are rewritten,
+// so concurrent runs on other workers skip them. Rows it can't decrypt are
+// reported and skipped.
+func (r *PostgresNotificationRepository) Reencrypt(ctx context.Context, afterID string, limit int) (ports.ReencryptBatch, error) {
+	var result ports.ReencryptBatch
+	if r.keyring == nil {
+		return result, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+	SELECT id, key_id, content_sealed, recipient_email, recipient_phone, recipient_device, title, body, data, html, attachments
+	FROM notifications
+	WHERE (key_id IS DISTINCT FROM $1 OR NOT content_sealed) AND id > $2
+	ORDER BY id
+	LIMIT $3
+	FOR UPDATE SKIP LOCKED
+	`, r.keyring.CurrentKeyID(), afterID, limit)
+	if err != nil {
+		return result, fmt.Errorf("failed to query notifications to re-encrypt: %w", err)
+	}
+
+	type stored struct {
+		id            string
+		keyID         sql.NullString
+		contentSealed bool
+		fields        encryption.Fields
+	}
+
+	var batch []stored
+	for rows.Next() {
+		var s stored
+		var title string
+		var email, phone, device, body, data, html, attachments sql.NullString
+		if err := rows.Scan(&s.id, &s.keyID, &s.contentSealed, &email, &phone, &device, &title, &body, &data, &html, &attachments); err != nil {
+			rows.Close()
+			return result, err
+		}
+		s.fields = encryption.Fields{
+			Email:       utils.SqlNullableString(email),
+			Phone:       utils.SqlNullableString(phone),
+			Device:      utils.SqlNullableString(device),
+			Title:       &title,
+			Body:        utils.SqlNullableString(body),
+			Data:        utils.SqlNullableString(data),
+			HTML:        utils.SqlNullableString(html),
+			Attachments: utils.SqlNullableString(attachments),
+		}
+		batch = append(batch, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	for _, s := range batch {
+		result.LastID = s.id
+
+		open := r.keyring.Open
+		if !s.contentSealed {
+			open = r.keyring.OpenLegacy
+		}
+
+		fields, err := open(s.id, s.keyID.String, s.fields)
+		if err != nil {
+			result.Skipped = append(result.Skipped, err)
+			continue
+		}
+
+		sealed, err := r.keyring.Seal(s.id, fields)
+		if err != nil {
+			return result, err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+		UPDATE notifications
+		SET recipient_email = $1, recipient_phone = $2, recipient_device = $3, title = $4, body = $5, data = $6,
+			html = $7, attachments = $8, key_id = $9, recipient_email_bidx = $10, recipient_phone_bidx = $11,
+			content_sealed = TRUE
+		WHERE id = $12
+		`, sealed.Email, sealed.Phone, sealed.Device, sealed.Title, sealed.Body, sealed.Data,
+			sealed.HTML, sealed.Attachments, sealed.KeyID, sealed.EmailIndex, sealed.PhoneIndex, s.id)
+		if err != nil {
+			return result, fmt.Errorf("failed to re-encrypt notification %s: %w", s.id, err)
+		}
+		result.Moved++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ports.ReencryptBatch{}, err
+	}
+
+	return result, nil
+}
+
+func (r *PostgresNotificationRepository) queryNotifications(ctx context.Context, query string, args ...any) ([]*domain.Notification, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*domain.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows, r.keyring)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// currentVersion reads the version the conditional updates are guarded by
+func (r *PostgresNotificationRepository) currentVersion(ctx context.Context, id string) (int, error) {
+	var version int
+	err := r.db.QueryRowContext(ctx, `SELECT version FROM notifications WHERE id = $1`, id).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("%w: %s", domain.ErrNotificationNotFound, id)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get notification version: %w", err)
+	}
+	return version, nil
+}
+
+// requireUpdated reports a conflict when the version guard matched no row
+func requireUpdated(result sql.Result, id string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w for notification %s", domain.ErrNotificationConflict, id)
+	}
+	return nil
+}
+
+func (r *PostgresNotificationRepository) Close() error {
+	return r.db.Close()
+}
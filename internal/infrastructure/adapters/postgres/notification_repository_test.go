@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/repositorytest"
+)
+
+// The contracts run against the database in POSTGRES_TEST_DSN, whose tables
+// are emptied before every case
+func TestNotificationRepository(t *testing.T) {
+	dsn := testDSN(t)
+
+	repositorytest.RunNotificationRepository(t, func(t *testing.T) ports.NotificationRepository {
+		repo, err := NewPostgresNotificationRepository(dsn, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		truncate(t, dsn, "notifications")
+		return repo
+	})
+}
+
+func TestCampaignRepository(t *testing.T) {
+	dsn := testDSN(t)
+
+	repositorytest.RunCampaignRepository(t, func(t *testing.T) ports.CampaignRepository {
+		repo, err := NewPostgresCampaignRepository(dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		truncate(t, dsn, "campaigns")
+		return repo
+	})
+}
+
+func TestTemplateRepository(t *testing.T) {
+	dsn := testDSN(t)
+
+	repositorytest.RunTemplateRepository(t, func(t *testing.T) ports.TemplateRepository {
+		repo, err := NewPostgresTemplateRepository(dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		truncate(t, dsn, "email_templates")
+		return repo
+	})
+}
+
+func testDSN(t *testing.T) string {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set")
+	}
+	return dsn
+}
+
+func truncate(t *testing.T, dsn, table string) {
+	t.Helper()
+
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`TRUNCATE ` + table); err != nil {
+		t.Fatal(err)
+	}
+}
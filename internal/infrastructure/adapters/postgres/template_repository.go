@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+)
+
+// PostgresTemplateRepository shares published templates between all replicas
+type PostgresTemplateRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresTemplateRepository(dsn string) (ports.TemplateRepository, error) {
+	db, err := open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostgresTemplateRepository{db: db}, nil
+}
+
+const templateColumns = `name, version, body, status, created_at, updated_at, published_at`
+
+func scanTemplate(row rowScanner) (*domain.TemplateVersion, error) {
+	var t domain.TemplateVersion
+	var status string
+	var publishedAt sql.NullTime
+
+	if err := row.Scan(&t.Name, &t.Version, &t.Body, &status, &t.CreatedAt, &t.UpdatedAt, &publishedAt); err != nil {
+		return nil, err
+	}
+
+	t.Status = domain.TemplateStatus(status)
+	t.PublishedAt = nullableTime(publishedAt)
+	return &t, nil
+}
+
+func (r *PostgresTemplateRepository) CreateVersion(ctx context.Context, t *domain.TemplateVersion) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Versions of one template are allocated one at a time
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "email_templates/"+t.Name); err != nil {
+		return fmt.Errorf("failed to lock template: %w", err)
+	}
+
+	var version int
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) + 1 FROM email_templates WHERE name = $1`, t.Name).Scan(&version)
+	if err != nil {
+		return fmt.Errorf("failed to allocate template version: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO email_templates (`+templateColumns+`)
+	VALUES ($1, $2, $3, $4, $5, $6, NULL)
+	`, t.Name, version, t.Body, string(t.Status), t.CreatedAt, t.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save template: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	t.Version = version
+	return nil
+}
+
+func (r *PostgresTemplateRepository) UpdateVersion(ctx context.Context, t *domain.TemplateVersion) error {
+	result, err := r.db.ExecContext(ctx, `
+	UPDATE email_templates SET body = $1, updated_at = $2
+	WHERE name = $3 AND version = $4 AND status = 'DRAFT'
+	`, t.Body, t.UpdatedAt, t.Name, t.Version)
+	if err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+
+	return requireAffected(result, domain.ErrTemplateNotDraft)
+}
+
+func (r *PostgresTemplateRepository) DeleteVersion(ctx context.Context, name string, version int) error {
+	result, err := r.db.ExecContext(ctx, `
+	DELETE FROM email_templates WHERE name = $1 AND version = $2 AND status = 'DRAFT'
+	`, name, version)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	return requireAffected(result, domain.ErrTemplateNotDraft)
+}
+
+func (r *PostgresTemplateRepository) FindVersion(ctx context.Context, name string, version int) (*domain.TemplateVersion, error) {
+	query := `SELECT ` + templateColumns + ` FROM email_templates WHERE name = $1 AND version = $2`
+
+	t, err := scanTemplate(r.db.QueryRowContext(ctx, query, name, version))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan template: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *PostgresTemplateRepository) ListVersions(ctx context.Context, name string) ([]*domain.TemplateVersion, error) {
+	query := `SELECT ` + templateColumns + ` FROM email_templates WHERE name = $1 ORDER BY version DESC`
+	return r.queryTemplates(ctx, query, name)
+}
+
+func (r *PostgresTemplateRepository) FindPublished(ctx context.Context) ([]*domain.TemplateVersion, error) {
+	query := `SELECT ` + templateColumns + ` FROM email_templates WHERE status = 'PUBLISHED' ORDER BY name`
+	return r.queryTemplates(ctx, query)
+}
+
+func (r *PostgresTemplateRepository) Publish(ctx context.Context, name string, version int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the template's rows so concurrent publishes don't both archive
+	// the old version and leave two published
+	rows, err := tx.QueryContext(ctx, `SELECT version FROM email_templates WHERE name = $1 FOR UPDATE`, name)
+	if err != nil {
+		return err
+	}
+	exists := false
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			rows.Close()
+			return err
+		}
+		exists = exists || v == version
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrTemplateNotFound
+	}
+
+	now := time.Now().UTC()
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE email_templates SET status = 'ARCHIVED', updated_at = $1
+	WHERE name = $2 AND status = 'PUBLISHED'
+	`, now, name)
+	if err != nil {
+		return fmt.Errorf("failed to archive published template: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE email_templates SET status = 'PUBLISHED', published_at = $1, updated_at = $1
+	WHERE name = $2 AND version = $3
+	`, now, name, version)
+	if err != nil {
+		return fmt.Errorf("failed to publish template: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresTemplateRepository) Unpublish(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, `
+	UPDATE email_templates SET status = 'ARCHIVED', updated_at = $1
+	WHERE name = $2 AND status = 'PUBLISHED'
+	`, time.Now().UTC(), name)
+	if err != nil {
+		return fmt.Errorf("failed to unpublish template: %w", err)
+	}
+
+	return requireAffected(result, domain.ErrTemplateNotFound)
+}
+
+func (r *PostgresTemplateRepository) queryTemplates(ctx context.Context, query string, args ...any) ([]*domain.TemplateVersion, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*domain.TemplateVersion
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+
+	return templates, rows.Err()
+}
+
+func (r *PostgresTemplateRepository) Close() error {
+	return r.db.Close()
+}
@@ -0,0 +1,174 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	mimemessage "github.com/commitshark/notification-svc/internal/infrastructure/adapters/mime"
+)
+
+const maxAttachmentRedirects = 5
+
+var (
+	errAttachmentURL     = errors.New("attachment url not allowed")
+	errAttachmentAddress = errors.New("attachment host resolves to a non-public address")
+)
+
+// AttachmentFetcher downloads attachments given by URL. It only fetches https
+// URLs on the allowed hosts, and never connects to loopback, private or
+// link-local addresses whatever the host name resolves to.
+type AttachmentFetcher struct {
+	allowedHosts []string
+	client       *http.Client
+}
+
+// NewAttachmentFetcher allows exactly the given hosts; "*.example.com" allows
+// the subdomains of example.com. Without hosts no URL is fetched.
+func NewAttachmentFetcher(allowedHosts []string) *AttachmentFetcher {
+	f := &AttachmentFetcher{}
+	for _, host := range allowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			f.allowedHosts = append(f.allowedHosts, host)
+		}
+	}
+
+	// The address is checked after DNS resolution, right before connecting, so
+	// a host can't pass the allowlist and then resolve somewhere internal
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: checkAttachmentAddress,
+	}
+
+	f.client = &http.Client{
+		Timeout: 15 * time.Second,
+		Transport: &http.Transport{
+			// A proxy would connect on our behalf and skip the address check
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxAttachmentRedirects {
+				return fmt.Errorf("%w: too many redirects", errAttachmentURL)
+			}
+			return f.checkURL(req.URL)
+		},
+	}
+
+	return f
+}
+
+// checkURL accepts https URLs on an allowed host
+func (f *AttachmentFetcher) checkURL(u *url.URL) error {
+	if u.Scheme != "https" {
+		return fmt.Errorf("%w: %s is not https", errAttachmentURL, u.Redacted())
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range f.allowedHosts {
+		if host == allowed {
+			return nil
+		}
+		if parent, ok := strings.CutPrefix(allowed, "*."); ok && strings.HasSuffix(host, "."+parent) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: host %q is not allowed", errAttachmentURL, host)
+}
+
+func checkAttachmentAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("%w: %s", errAttachmentAddress, host)
+	}
+
+	return nil
+}
+
+// Fetch downloads the attachment at rawURL. Refused URLs are permanent failures.
+func (f *AttachmentFetcher) Fetch(rawURL string) ([]byte, error) {
+	if f == nil {
+		return nil, domain.PermanentFailure(fmt.Errorf("%w: url attachments are disabled", errAttachmentURL))
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, domain.PermanentFailure(fmt.Errorf("invalid attachment url: %w", err))
+	}
+	if err := f.checkURL(u); err != nil {
+		return nil, domain.PermanentFailure(err)
+	}
+
+	resp, err := f.client.Get(u.String())
+	if err != nil {
+		err = fmt.Errorf("failed to fetch %s: %w", u.Redacted(), err)
+		if errors.Is(err, errAttachmentURL) || errors.Is(err, errAttachmentAddress) {
+			return nil, domain.PermanentFailure(err)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, httpStatusFailure(resp, fmt.Errorf("failed to fetch %s: status %d", u.Redacted(), resp.StatusCode))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, domain.MaxAttachmentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", u.Redacted(), err)
+	}
+
+	if len(data) > domain.MaxAttachmentSize {
+		return nil, domain.PermanentFailure(fmt.Errorf("%s exceeds %d bytes", u.Redacted(), domain.MaxAttachmentSize))
+	}
+
+	return data, nil
+}
+
+// resolveAttachments loads referenced attachment content and enforces the size limits
+// that could not be checked when the notification was created.
+func resolveAttachments(attachments []domain.Attachment, fetcher *AttachmentFetcher) ([]mimemessage.Attachment, error) {
+	resolved := make([]mimemessage.Attachment, 0, len(attachments))
+	total := 0
+
+	for _, a := range attachments {
+		data := a.Data
+		if len(data) == 0 && a.URL != "" {
+			var err error
+			data, err = fetcher.Fetch(a.URL)
+			if err != nil {
+				return nil, fmt.Errorf("attachment %s: %w", a.Filename, err)
+			}
+		}
+
+		total += len(data)
+		if total > domain.MaxAttachmentsTotalSize {
+			return nil, domain.PermanentFailure(fmt.Errorf("attachments exceed %d bytes in total", domain.MaxAttachmentsTotalSize))
+		}
+
+		resolved = append(resolved, mimemessage.Attachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			ContentID:   a.ContentID,
+			Inline:      a.IsInline(),
+			Data:        data,
+		})
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,134 @@
+package providers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
+
+func TestAttachmentFetcherCheckURL(t *testing.T) {
+	f := NewAttachmentFetcher([]string{" CDN.example.com ", "*.assets.example.com", ""})
+
+	tests := []struct {
+		url string
+		ok  bool
+	}{
+		{"https://cdn.example.com/ticket.pdf", true},
+		{"https://CDN.EXAMPLE.COM/ticket.pdf", true},
+		{"https://cdn.example.com:8443/ticket.pdf", true},
+		{"https://eu.assets.example.com/logo.png", true},
+		{"https://a.b.assets.example.com/logo.png", true},
+		{"https://assets.example.com/logo.png", false},
+		{"https://evilassets.example.com/logo.png", false},
+		{"https://cdn.example.com.evil.test/ticket.pdf", false},
+		{"http://cdn.example.com/ticket.pdf", false},
+		{"file:///etc/passwd", false},
+		{"https://169.254.169.254/latest/meta-data", false},
+	}
+
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = f.checkURL(u)
+		if tt.ok && err != nil {
+			t.Errorf("checkURL(%s) = %v, want allowed", tt.url, err)
+		}
+		if !tt.ok && !errors.Is(err, errAttachmentURL) {
+			t.Errorf("checkURL(%s) = %v, want errAttachmentURL", tt.url, err)
+		}
+	}
+}
+
+func TestCheckAttachmentAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		ok      bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:443", false},
+		{"[::1]:443", false},
+		{"10.0.0.1:443", false},
+		{"172.16.5.4:443", false},
+		{"192.168.1.1:443", false},
+		{"[fd00::1]:443", false},
+		{"169.254.169.254:80", false},
+		{"[fe80::1]:443", false},
+		{"0.0.0.0:443", false},
+		{"224.0.0.1:443", false},
+		{"example.com:443", false},
+	}
+
+	for _, tt := range tests {
+		err := checkAttachmentAddress("tcp", tt.address, nil)
+		if tt.ok && err != nil {
+			t.Errorf("checkAttachmentAddress(%s) = %v, want allowed", tt.address, err)
+		}
+		if !tt.ok && !errors.Is(err, errAttachmentAddress) {
+			t.Errorf("checkAttachmentAddress(%s) = %v, want errAttachmentAddress", tt.address, err)
+		}
+	}
+}
+
+func TestAttachmentFetcherRefusesLoopback(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secret"))
+	}))
+	defer server.Close()
+
+	// The host is allowed, but it resolves to loopback
+	u, _ := url.Parse(server.URL)
+	f := NewAttachmentFetcher([]string{u.Hostname()})
+
+	tests := []struct {
+		name    string
+		fetcher *AttachmentFetcher
+		url     string
+	}{
+		{"disabled", nil, server.URL},
+		{"invalid url", f, "https://%zz"},
+		{"host not allowed", NewAttachmentFetcher(nil), server.URL},
+		{"loopback address", f, server.URL + "/ticket.pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.fetcher.Fetch(tt.url)
+			if err == nil {
+				t.Fatalf("Fetch = %q, want an error", data)
+			}
+			if kind, _ := domain.ClassifyFailure(err); kind != domain.FailurePermanent {
+				t.Errorf("Fetch: %v is %s, want permanent", err, kind)
+			}
+		})
+	}
+}
+
+func TestResolveAttachments(t *testing.T) {
+	inline := domain.Attachment{Filename: "logo.png", ContentType: "image/png", ContentID: "logo", Disposition: domain.DispositionInline, Data: []byte("png")}
+	file := domain.Attachment{Filename: "ticket.pdf", ContentType: "application/pdf", Data: []byte("pdf")}
+
+	resolved, err := resolveAttachments([]domain.Attachment{inline, file}, nil)
+	if err != nil {
+		t.Fatalf("resolveAttachments: %v", err)
+	}
+	if len(resolved) != 2 || string(resolved[1].Data) != "pdf" || resolved[1].Filename != "ticket.pdf" {
+		t.Fatalf("resolved = %+v", resolved)
+	}
+	if !resolved[0].Inline || resolved[0].ContentID != "logo" {
+		t.Errorf("inline attachment = %+v", resolved[0])
+	}
+
+	big := domain.Attachment{Filename: "big.bin", Data: make([]byte, domain.MaxAttachmentsTotalSize/2+1)}
+	_, err = resolveAttachments([]domain.Attachment{big, big}, nil)
+	if kind, _ := domain.ClassifyFailure(err); err == nil || kind != domain.FailurePermanent {
+		t.Errorf("oversized attachments: err = %v, want a permanent failure", err)
+	}
+}
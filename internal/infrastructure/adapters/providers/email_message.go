@@ -0,0 +1,152 @@
+package providers
+
+import (
+	"fmt"
+	"html"
+	"net/mail"
+	"strings"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	domain_template "github.com/commitshark/notification-svc/internal/domain/templates"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/htmltext"
+	mimemessage "github.com/commitshark/notification-svc/internal/infrastructure/adapters/mime"
+)
+
+const defaultEmailFrom = "Eventor <noreply@eventor.com.ng>"
+
+// EmailComposer renders the notification content and composes the MIME message
+// shared by all SMTP based providers and the admin preview.
+type EmailComposer struct {
+	from        mail.Address
+	renderer    ports.TemplateRenderer
+	attachments *AttachmentFetcher
+}
+
+func NewEmailComposer(from string, renderer ports.TemplateRenderer, attachments *AttachmentFetcher) (*EmailComposer, error) {
+	if from == "" {
+		from = defaultEmailFrom
+	}
+
+	address, err := mimemessage.ParseAddress(from)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailComposer{
+		from:        address,
+		renderer:    renderer,
+		attachments: attachments,
+	}, nil
+}
+
+func (c *EmailComposer) Compose(n *domain.Notification) (*ports.ComposedEmail, error) {
+	if n.Recipient.Email == nil || *n.Recipient.Email == "" {
+		return nil, missingAddress("email", n.Type)
+	}
+
+	to, err := mimemessage.ParseAddress(*n.Recipient.Email)
+	if err != nil {
+		return nil, domain.PermanentFailure(err)
+	}
+
+	msg := mimemessage.Message{
+		From:    c.from,
+		To:      []mail.Address{to},
+		ReplyTo: &c.from,
+		Subject: n.Content.Title,
+	}
+
+	attachments := append([]domain.Attachment{}, n.Content.Attachments...)
+	templateVersion := 0
+
+	if n.Content.Template != nil && *n.Content.Template != "" && n.Content.Data != nil {
+		def, ok := domain_template.Lookup(*n.Content.Template)
+		if !ok {
+			return nil, domain.PermanentFailure(fmt.Errorf("unknown template name: %s", *n.Content.Template))
+		}
+
+		var emailData domain_template.EmailTemplateData
+		err := domain_template.ParseTemplateData(*n.Content.Template, *n.Content.Data, &emailData)
+		if err != nil {
+			return nil, domain.PermanentFailure(err)
+		}
+
+		subject, preHeader := c.renderer.Localize(*n.Content.Template, n.Content.Locale, n.Content.Title, def.PreHeaderFor(emailData), emailData)
+
+		html, err := c.renderer.Render(*n.Content.Template, n.Content.Locale, subject, emailData, preHeader)
+		if err != nil {
+			return nil, err
+		}
+
+		text, err := c.renderer.RenderText(*n.Content.Template, n.Content.Locale, subject, emailData, preHeader)
+		if err != nil {
+			return nil, err
+		}
+
+		templateVersion = c.renderer.Version(*n.Content.Template, n.Content.Locale)
+
+		msg.Subject = subject
+		msg.HTML = html
+		msg.Text = text
+		msg.Headers = mimemessage.HeadersFromMap(def.Headers)
+
+		if p, ok := emailData.(domain_template.AttachmentsProvider); ok {
+			files, err := p.GetAttachments(to.Address)
+			if err != nil {
+				return nil, err
+			}
+			attachments = append(attachments, files...)
+		}
+	} else if n.Content.Body != nil && *n.Content.Body != "" {
+		body := *n.Content.Body
+		if !looksLikeHTML(body) {
+			msg.Text = body
+			body = strings.ReplaceAll(html.EscapeString(body), "\n", "<br>\n")
+		}
+		msg.HTML = fmt.Sprintf("<!DOCTYPE html><html><body>%s</body></html>", body)
+	} else {
+		return nil, domain.PermanentFailure(fmt.Errorf("notification has neither template data nor body"))
+	}
+
+	msg.Attachments, err = resolveAttachments(attachments, c.attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every email goes out as multipart/alternative
+	if strings.TrimSpace(msg.Text) == "" {
+		msg.Text = htmltext.Convert(msg.HTML)
+	}
+
+	raw, err := msg.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ports.ComposedEmail{
+		Subject: msg.Subject,
+		HTML:    msg.HTML,
+		Text:    msg.Text,
+		MIME:    raw,
+
+		TemplateVersion: templateVersion,
+	}, nil
+}
+
+func looksLikeHTML(body string) bool {
+	return strings.Contains(body, "<") && strings.Contains(body, ">")
+}
+
+// signMessage applies the optional signer once the MIME message is final
+func signMessage(signer ports.MessageSigner, message []byte) ([]byte, error) {
+	if signer == nil {
+		return message, nil
+	}
+
+	signed, err := signer.Sign(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+	return signed, nil
+}
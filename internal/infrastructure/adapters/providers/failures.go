@@ -0,0 +1,62 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
+
+// classifySMTPError makes 5xx replies permanent failures. 4xx replies and
+// connection errors stay transient.
+func classifySMTPError(err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return domain.PermanentFailure(err)
+	}
+	return err
+}
+
+// httpStatusFailure classifies an unsuccessful response: 429 is rate limited,
+// other 4xx except 408 are permanent and the rest transient.
+func httpStatusFailure(resp *http.Response, err error) error {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return domain.RateLimitedFailure(err, parseRetryAfter(resp.Header.Get("Retry-After")))
+	case resp.StatusCode == http.StatusRequestTimeout:
+		return err
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return domain.PermanentFailure(err)
+	default:
+		return err
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// missingAddress is the permanent failure for a notification without the
+// address its channel needs
+func missingAddress(what string, channel domain.NotificationType) error {
+	return domain.PermanentFailure(fmt.Errorf("%s missing for %s", what, channel))
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/commitshark/notification-svc/internal/domain"
@@ -33,12 +35,12 @@ func (p *HttpEmailProvider) Name() string {
 
 func (p *HttpEmailProvider) Send(n *domain.Notification, isMarketing bool) (string, error) {
 	if n.Recipient.Email == nil || *n.Recipient.Email == "" {
-		return "", fmt.Errorf("email missing for SMS")
+		return "", missingAddress("email", n.Type)
 	}
 
 	payloadBytes, err := json.Marshal(n)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal notification: %w", err)
+		return "", domain.PermanentFailure(fmt.Errorf("failed to marshal notification: %w", err))
 	}
 
 	url := p.URL
@@ -61,12 +63,20 @@ func (p *HttpEmailProvider) Send(n *domain.Notification, isMarketing bool) (stri
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
-		return "", fmt.Errorf("remote email provider returned status %d", resp.StatusCode)
+		// The sender explains the failure in the body, keep it for the provider response
+		reason, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", httpStatusFailure(resp, fmt.Errorf("remote email provider returned status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(reason))))
 	}
 
 	var responseBody map[string]interface{}
 	json.NewDecoder(resp.Body).Decode(&responseBody)
 
+	// The sender reports which stored template version it rendered
+	if v, ok := responseBody["template_version"].(float64); ok {
+		n.RecordTemplateVersion(int(v))
+	}
+
 	return fmt.Sprintf("email sent via http provider: %v", responseBody), nil
 }
 
@@ -4,13 +4,17 @@ import (
 	"fmt"
 
 	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
 )
 
 type PushProvider struct {
+	renderer ports.ShortMessageRenderer
 }
 
-func NewPushProvider() *PushProvider {
-	return &PushProvider{}
+func NewPushProvider(renderer ports.ShortMessageRenderer) *PushProvider {
+	return &PushProvider{
+		renderer: renderer,
+	}
 }
 
 func (p *PushProvider) Name() string {
@@ -23,16 +27,17 @@ func (p *PushProvider) Supports(notificationType domain.NotificationType) bool {
 
 func (p *PushProvider) Send(n *domain.Notification, isMarketing bool) (string, error) {
 	if n.Recipient.DeviceID == nil || *n.Recipient.DeviceID == "" {
-		return "", fmt.Errorf("device id missing for SMS")
+		return "", missingAddress("device id", n.Type)
 	}
 
-	if n.Content.Body == nil || *n.Content.Body == "" {
-		return "", fmt.Errorf("message content for SMS")
+	msg, err := composeShortMessage(p.renderer, n)
+	if err != nil {
+		return "", fmt.Errorf("message content for push: %w", err)
 	}
 
 	// Example; replace with actual API call
-	fmt.Printf("[SMS] Sending to %s: %s\n", *n.Recipient.DeviceID, *n.Content.Body)
+	fmt.Printf("[Push] Sending to %s: %s - %s (%s)\n", *n.Recipient.DeviceID, msg.Title, msg.Body, msg.Link)
 
 	// Return a fake provider message ID
-	return "", fmt.Errorf("Not Implemented")
+	return "", domain.PermanentFailure(fmt.Errorf("Not Implemented"))
 }
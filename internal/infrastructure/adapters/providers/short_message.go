@@ -0,0 +1,44 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	domain_template "github.com/commitshark/notification-svc/internal/domain/templates"
+)
+
+// composeShortMessage uses the pre-rendered body when there is one and otherwise
+// renders the channel's variant of the notification template.
+func composeShortMessage(renderer ports.ShortMessageRenderer, n *domain.Notification) (*ports.ShortMessage, error) {
+	if n.Content.Body != nil && *n.Content.Body != "" {
+		return &ports.ShortMessage{
+			Title: n.Content.Title,
+			Body:  *n.Content.Body,
+		}, nil
+	}
+
+	if n.Content.Template == nil || *n.Content.Template == "" || n.Content.Data == nil {
+		return nil, domain.PermanentFailure(fmt.Errorf("notification has neither template data nor body"))
+	}
+
+	if renderer == nil {
+		return nil, domain.PermanentFailure(fmt.Errorf("no renderer configured for %s templates", n.Type))
+	}
+
+	var data domain_template.EmailTemplateData
+	if err := domain_template.ParseTemplateData(*n.Content.Template, *n.Content.Data, &data); err != nil {
+		return nil, domain.PermanentFailure(err)
+	}
+
+	msg, err := renderer.RenderShort(n.Type, *n.Content.Template, n.Content.Locale, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.Title == "" {
+		msg.Title = n.Content.Title
+	}
+
+	return msg, nil
+}
@@ -4,13 +4,17 @@ import (
 	"fmt"
 
 	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
 )
 
 type SMSProvider struct {
+	renderer ports.ShortMessageRenderer
 }
 
-func NewSMSProvider() *SMSProvider {
-	return &SMSProvider{}
+func NewSMSProvider(renderer ports.ShortMessageRenderer) *SMSProvider {
+	return &SMSProvider{
+		renderer: renderer,
+	}
 }
 
 func (p *SMSProvider) Name() string {
@@ -23,16 +27,17 @@ func (p *SMSProvider) Supports(notificationType domain.NotificationType) bool {
 
 func (p *SMSProvider) Send(n *domain.Notification, isMarketing bool) (string, error) {
 	if n.Recipient.Phone == nil || *n.Recipient.Phone == "" {
-		return "", fmt.Errorf("phone number missing for SMS")
+		return "", missingAddress("phone number", n.Type)
 	}
 
-	if n.Content.Body == nil || *n.Content.Body == "" {
-		return "", fmt.Errorf("message content for SMS")
+	msg, err := composeShortMessage(p.renderer, n)
+	if err != nil {
+		return "", fmt.Errorf("message content for SMS: %w", err)
 	}
 
 	// Example; replace with actual API call
-	fmt.Printf("[SMS] Sending to %s: %s\n", *n.Recipient.Phone, *n.Content.Body)
+	fmt.Printf("[SMS] Sending to %s (%d segment(s)): %s\n", *n.Recipient.Phone, domain.SMSSegments(msg.Body), msg.Body)
 
 	// Return a fake provider message ID
-	return "", fmt.Errorf("Not Implemented")
+	return "", domain.PermanentFailure(fmt.Errorf("Not Implemented"))
 }
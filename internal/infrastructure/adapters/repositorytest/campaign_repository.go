@@ -0,0 +1,128 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/google/uuid"
+)
+
+// RunCampaignRepository runs the contract against the empty repositories open returns
+func RunCampaignRepository(t *testing.T, open func(t *testing.T) ports.CampaignRepository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo ports.CampaignRepository)
+	}{
+		{"SaveAndFind", testCampaignSaveAndFind},
+		{"SaveConflict", testCampaignSaveConflict},
+		{"FindActive", testCampaignFindActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := open(t)
+			defer repo.Close()
+			tt.run(t, repo)
+		})
+	}
+}
+
+func newCampaign(t *testing.T) *domain.Campaign {
+	t.Helper()
+
+	c, err := domain.NewCampaign(uuid.NewString(), "Launch", domain.EmailNotification, "welcome", "Hello", "fr",
+		map[string]interface{}{"promo": "SPRING"}, []string{"u1", "u2"}, 10)
+	if err != nil {
+		t.Fatalf("NewCampaign: %v", err)
+	}
+
+	// Stores keep second precision
+	c.CreatedAt = c.CreatedAt.Truncate(time.Second)
+	c.UpdatedAt = c.CreatedAt
+	return c
+}
+
+func testCampaignSaveAndFind(t *testing.T, repo ports.CampaignRepository) {
+	ctx := context.Background()
+	c := newCampaign(t)
+	if err := repo.Save(ctx, c); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.Name != c.Name || got.Locale != c.Locale || got.Data["promo"] != "SPRING" ||
+		len(got.Audience.UserIDs) != 2 || !got.CreatedAt.Equal(c.CreatedAt) {
+		t.Errorf("got %+v, want %+v", got, c)
+	}
+
+	if _, err := repo.FindByID(ctx, uuid.NewString()); !errors.Is(err, domain.ErrCampaignNotFound) {
+		t.Errorf("missing campaign: err = %v", err)
+	}
+}
+
+func testCampaignSaveConflict(t *testing.T, repo ports.CampaignRepository) {
+	ctx := context.Background()
+	c := newCampaign(t)
+	if err := repo.Save(ctx, c); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	first, _ := repo.FindByID(ctx, c.ID)
+	second, _ := repo.FindByID(ctx, c.ID)
+
+	now := time.Now()
+	if err := first.Launch(nil, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(ctx, first); err != nil {
+		t.Fatalf("Save first: %v", err)
+	}
+
+	if err := second.Launch(nil, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(ctx, second); !errors.Is(err, domain.ErrCampaignConflict) {
+		t.Errorf("stale save: err = %v, want ErrCampaignConflict", err)
+	}
+}
+
+func testCampaignFindActive(t *testing.T, repo ports.CampaignRepository) {
+	ctx := context.Background()
+
+	draft := newCampaign(t)
+	if err := repo.Save(ctx, draft); err != nil {
+		t.Fatal(err)
+	}
+
+	scheduled := newCampaign(t)
+	at := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := scheduled.Launch(&at, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(ctx, scheduled); err != nil {
+		t.Fatal(err)
+	}
+
+	active, err := repo.FindActive(ctx)
+	if err != nil {
+		t.Fatalf("FindActive: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != scheduled.ID {
+		t.Fatalf("active = %v, want only %s", active, scheduled.ID)
+	}
+	if active[0].ScheduledAt == nil || !active[0].ScheduledAt.Equal(at) {
+		t.Errorf("scheduled_at = %v, want %v", active[0].ScheduledAt, at)
+	}
+
+	all, err := repo.List(ctx)
+	if err != nil || len(all) != 2 {
+		t.Errorf("List = %d campaigns, %v, want 2", len(all), err)
+	}
+}
@@ -0,0 +1,488 @@
+// Package repositorytest holds the behaviour every notification, campaign and
+// template repository implementation must share, run by each adapter's tests.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/google/uuid"
+)
+
+// OpenFunc returns an empty repository, closed by the caller
+type OpenFunc func(t *testing.T) ports.NotificationRepository
+
+// RunNotificationRepository runs the contract against the repositories open returns
+func RunNotificationRepository(t *testing.T, open OpenFunc) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo ports.NotificationRepository)
+	}{
+		{"SaveAndFind", testSaveAndFind},
+		{"FindMissing", testFindMissing},
+		{"SaveConflict", testSaveConflict},
+		{"UpdateStatus", testUpdateStatus},
+		{"IncrementRetryCount", testIncrementRetryCount},
+		{"FindPending", testFindPending},
+		{"ClaimPending", testClaimPending},
+		{"Filters", testFilters},
+		{"CountAndIDs", testCountAndIDs},
+		{"DeleteByIDs", testDeleteByIDs},
+		{"DeleteByIDsRechecksFilter", testDeleteByIDsRechecksFilter},
+		{"DeleteByIDsArchivesDeleted", testDeleteByIDsArchivesDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := open(t)
+			defer repo.Close()
+			tt.run(t, repo)
+		})
+	}
+}
+
+func newNotification(t *testing.T, email string) *domain.Notification {
+	t.Helper()
+
+	body := "Your code is 123456"
+	html := "<p>123456</p>"
+	template := "otp"
+	data := map[string]interface{}{"otp_code": "123456"}
+	phone := "+2348000000000"
+
+	n, err := domain.NewNotification(uuid.NewString(), domain.EmailNotification, domain.Recipient{
+		ID:    uuid.NewString(),
+		Email: &email,
+		Phone: &phone,
+	}, domain.Content{
+		Title:    "Your code",
+		Body:     &body,
+		HTML:     &html,
+		Template: &template,
+		Data:     &data,
+		Attachments: []domain.Attachment{
+			{Filename: "ticket.ics", ContentType: "text/calendar", Data: []byte("BEGIN:VCALENDAR")},
+		},
+		Locale: "fr",
+	}, 3, 0)
+	if err != nil {
+		t.Fatalf("NewNotification: %v", err)
+	}
+
+	hash := "3f2a9c"
+	n.RequestHash = &hash
+
+	// Stores keep second precision
+	n.CreatedAt = n.CreatedAt.Truncate(time.Second)
+	return n
+}
+
+func save(t *testing.T, repo ports.NotificationRepository, n *domain.Notification) {
+	t.Helper()
+	if err := repo.Save(context.Background(), n); err != nil {
+		t.Fatalf("Save %s: %v", n.ID, err)
+	}
+}
+
+func find(t *testing.T, repo ports.NotificationRepository, id string) *domain.Notification {
+	t.Helper()
+	n, err := repo.FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("FindByID %s: %v", id, err)
+	}
+	return n
+}
+
+func testSaveAndFind(t *testing.T, repo ports.NotificationRepository) {
+	n := newNotification(t, "alice@example.com")
+	save(t, repo, n)
+
+	got := find(t, repo, n.ID)
+	if *got.Recipient.Email != "alice@example.com" || *got.Recipient.Phone != "+2348000000000" {
+		t.Errorf("recipient = %+v", got.Recipient)
+	}
+	if *got.Content.Body != *n.Content.Body || *got.Content.HTML != *n.Content.HTML {
+		t.Errorf("content = %q / %q", *got.Content.Body, *got.Content.HTML)
+	}
+	if (*got.Content.Data)["otp_code"] != "123456" {
+		t.Errorf("data = %v", *got.Content.Data)
+	}
+	if len(got.Content.Attachments) != 1 || string(got.Content.Attachments[0].Data) != "BEGIN:VCALENDAR" {
+		t.Errorf("attachments = %+v", got.Content.Attachments)
+	}
+	if got.Content.Locale != "fr" || got.Status != domain.StatusPending || got.Version != 1 {
+		t.Errorf("locale %q, status %s, version %d", got.Content.Locale, got.Status, got.Version)
+	}
+	if !got.CreatedAt.Equal(n.CreatedAt) {
+		t.Errorf("created_at = %v, want %v", got.CreatedAt, n.CreatedAt)
+	}
+	if got.RequestHash == nil || *got.RequestHash != *n.RequestHash {
+		t.Errorf("request hash = %v, want %q", got.RequestHash, *n.RequestHash)
+	}
+
+	got.MarkAsFailed("timeout", time.Minute)
+	save(t, repo, got)
+
+	failed := find(t, repo, n.ID)
+	if failed.Status != domain.StatusFailed || failed.RetryCount != 1 || failed.Version != 2 {
+		t.Errorf("after failure: status %s, retries %d, version %d", failed.Status, failed.RetryCount, failed.Version)
+	}
+	if failed.NextAttemptAt == nil || failed.NextAttemptAt.Before(time.Now()) {
+		t.Errorf("next attempt = %v, want in the future", failed.NextAttemptAt)
+	}
+}
+
+func testFindMissing(t *testing.T, repo ports.NotificationRepository) {
+	_, err := repo.FindByID(context.Background(), uuid.NewString())
+	if !errors.Is(err, domain.ErrNotificationNotFound) {
+		t.Errorf("err = %v, want ErrNotificationNotFound", err)
+	}
+}
+
+func testSaveConflict(t *testing.T, repo ports.NotificationRepository) {
+	n := newNotification(t, "bob@example.com")
+	save(t, repo, n)
+
+	first := find(t, repo, n.ID)
+	second := find(t, repo, n.ID)
+
+	if err := first.MarkAsSent("ok"); err != nil {
+		t.Fatal(err)
+	}
+	save(t, repo, first)
+
+	second.MarkAsFailed("timeout", time.Minute)
+	if err := repo.Save(context.Background(), second); !errors.Is(err, domain.ErrNotificationConflict) {
+		t.Errorf("stale save: err = %v, want ErrNotificationConflict", err)
+	}
+
+	if got := find(t, repo, n.ID); got.Status != domain.StatusSent {
+		t.Errorf("status = %s, the stale save won", got.Status)
+	}
+}
+
+func testUpdateStatus(t *testing.T, repo ports.NotificationRepository) {
+	ctx := context.Background()
+	n := newNotification(t, "carol@example.com")
+	save(t, repo, n)
+
+	if err := repo.UpdateStatus(ctx, n.ID, domain.StatusSent, "ok"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	got := find(t, repo, n.ID)
+	if got.Status != domain.StatusSent || got.ProviderResponse != "ok" || got.SentAt == nil || got.Version != 2 {
+		t.Errorf("status %s, response %q, sent at %v, version %d", got.Status, got.ProviderResponse, got.SentAt, got.Version)
+	}
+
+	if err := repo.UpdateStatus(ctx, uuid.NewString(), domain.StatusSent, "ok"); !errors.Is(err, domain.ErrNotificationNotFound) {
+		t.Errorf("missing: err = %v, want ErrNotificationNotFound", err)
+	}
+
+	// A save from before the update is stale now
+	n.MarkAsFailed("timeout", time.Minute)
+	if err := repo.Save(ctx, n); !errors.Is(err, domain.ErrNotificationConflict) {
+		t.Errorf("stale save: err = %v, want ErrNotificationConflict", err)
+	}
+}
+
+func testIncrementRetryCount(t *testing.T, repo ports.NotificationRepository) {
+	ctx := context.Background()
+	n := newNotification(t, "dave@example.com")
+	save(t, repo, n)
+
+	for range 2 {
+		if err := repo.IncrementRetryCount(ctx, n.ID); err != nil {
+			t.Fatalf("IncrementRetryCount: %v", err)
+		}
+	}
+
+	if got := find(t, repo, n.ID); got.RetryCount != 2 || got.Version != 3 {
+		t.Errorf("retries %d, version %d", got.RetryCount, got.Version)
+	}
+
+	if err := repo.IncrementRetryCount(ctx, uuid.NewString()); !errors.Is(err, domain.ErrNotificationNotFound) {
+		t.Errorf("missing: err = %v, want ErrNotificationNotFound", err)
+	}
+}
+
+func testFindPending(t *testing.T, repo ports.NotificationRepository) {
+	due := newNotification(t, "due@example.com")
+	save(t, repo, due)
+
+	scheduled := newNotification(t, "scheduled@example.com")
+	scheduled.MarkAsFailed("timeout", time.Hour)
+	save(t, repo, scheduled)
+
+	sent := newNotification(t, "sent@example.com")
+	if err := sent.MarkAsSent("ok"); err != nil {
+		t.Fatal(err)
+	}
+	save(t, repo, sent)
+
+	exhausted := newNotification(t, "exhausted@example.com")
+	exhausted.MaxRetries = 1
+	exhausted.MarkAsFailed("timeout", -time.Minute)
+	save(t, repo, exhausted)
+
+	retry := newNotification(t, "retry@example.com")
+	retry.MarkAsFailed("timeout", -time.Minute)
+	save(t, repo, retry)
+
+	pending, err := repo.FindPending(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("FindPending: %v", err)
+	}
+
+	got := ids(pending)
+	if len(got) != 2 || !got[due.ID] || !got[retry.ID] {
+		t.Errorf("pending = %v, want %s and %s", got, due.ID, retry.ID)
+	}
+}
+
+func testClaimPending(t *testing.T, repo ports.NotificationRepository) {
+	ctx := context.Background()
+
+	const total = 10
+	for range total {
+		save(t, repo, newNotification(t, "claim@example.com"))
+	}
+
+	until := time.Now().Add(time.Minute)
+
+	var mu sync.Mutex
+	claimed := map[string]int{}
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			batch, err := repo.ClaimPending(ctx, total, until)
+			if err != nil {
+				t.Errorf("ClaimPending: %v", err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, n := range batch {
+				claimed[n.ID]++
+				if n.NextAttemptAt == nil || !n.NextAttemptAt.Equal(until) {
+					t.Errorf("claimed %s with lease %v, want %v", n.ID, n.NextAttemptAt, until)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(claimed) != total {
+		t.Errorf("claimed %d notifications, want %d", len(claimed), total)
+	}
+	for id, count := range claimed {
+		if count > 1 {
+			t.Errorf("%s claimed %d times", id, count)
+		}
+	}
+
+	again, err := repo.ClaimPending(ctx, total, until)
+	if err != nil {
+		t.Fatalf("ClaimPending: %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("claimed %d leased notifications again", len(again))
+	}
+}
+
+func testFilters(t *testing.T, repo ports.NotificationRepository) {
+	ctx := context.Background()
+
+	old := newNotification(t, "Erin@Example.com")
+	old.CreatedAt = time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	save(t, repo, old)
+
+	recent := newNotification(t, "frank@example.com")
+	recent.IsMarketing = 1
+	save(t, repo, recent)
+
+	day := time.Now().Add(-24 * time.Hour)
+	marketing := true
+
+	cases := []struct {
+		name   string
+		filter domain.NotificationFilter
+		want   *domain.Notification
+	}{
+		{"recipient email ignores case", domain.NotificationFilter{Recipient: "erin@EXAMPLE.com"}, old},
+		{"recipient id", domain.NotificationFilter{Recipient: recent.Recipient.ID}, recent},
+		{"query by email", domain.NotificationFilter{Query: "frank@example.com"}, recent},
+		{"created before", domain.NotificationFilter{CreatedBefore: &day}, old},
+		{"created after", domain.NotificationFilter{CreatedAfter: &day}, recent},
+		{"marketing", domain.NotificationFilter{IsMarketing: &marketing}, recent},
+	}
+
+	for _, c := range cases {
+		list, total, err := repo.PaginatedList(ctx, 1, 10, c.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if total != 1 || len(list) != 1 || list[0].ID != c.want.ID {
+			t.Errorf("%s: got %d (%v), want %s", c.name, total, ids(list), c.want.ID)
+		}
+	}
+}
+
+func testCountAndIDs(t *testing.T, repo ports.NotificationRepository) {
+	ctx := context.Background()
+
+	var all []string
+	for i := range 3 {
+		n := newNotification(t, "count@example.com")
+		n.CreatedAt = n.CreatedAt.Add(time.Duration(i) * time.Second)
+		if i == 0 {
+			if err := n.MarkAsSent("ok"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		save(t, repo, n)
+		all = append(all, n.ID)
+	}
+
+	counts, err := repo.CountByStatus(ctx, domain.NotificationFilter{})
+	if err != nil {
+		t.Fatalf("CountByStatus: %v", err)
+	}
+	if counts[domain.StatusSent] != 1 || counts[domain.StatusPending] != 2 {
+		t.Errorf("counts = %v", counts)
+	}
+
+	pending := domain.StatusPending
+	found, err := repo.FindIDs(ctx, domain.NotificationFilter{Status: &pending})
+	if err != nil {
+		t.Fatalf("FindIDs: %v", err)
+	}
+	if len(found) != 2 || found[0] != all[1] || found[1] != all[2] {
+		t.Errorf("ids = %v, want %v oldest first", found, all[1:])
+	}
+}
+
+func testDeleteByIDs(t *testing.T, repo ports.NotificationRepository) {
+	ctx := context.Background()
+
+	keep := newNotification(t, "keep@example.com")
+	save(t, repo, keep)
+	gone := newNotification(t, "gone@example.com")
+	save(t, repo, gone)
+
+	deleted, err := repo.DeleteByIDs(ctx, []string{gone.ID, uuid.NewString()}, domain.NotificationFilter{}, nil)
+	if err != nil {
+		t.Fatalf("DeleteByIDs: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+
+	if _, err := repo.FindByID(ctx, gone.ID); !errors.Is(err, domain.ErrNotificationNotFound) {
+		t.Errorf("deleted notification: err = %v", err)
+	}
+	find(t, repo, keep.ID)
+
+	if err := repo.Compact(ctx); err != nil {
+		t.Errorf("Compact: %v", err)
+	}
+}
+
+func testDeleteByIDsRechecksFilter(t *testing.T, repo ports.NotificationRepository) {
+	ctx := context.Background()
+
+	exhausted := newNotification(t, "exhausted@example.com")
+	for i := 0; i < exhausted.MaxRetries; i++ {
+		exhausted.MarkAsFailed("timeout", time.Minute)
+	}
+	save(t, repo, exhausted)
+
+	retrying := newNotification(t, "retrying@example.com")
+	retrying.MarkAsFailed("timeout", time.Minute)
+	save(t, repo, retrying)
+
+	// Failed when it was selected, pending again by the time it is deleted
+	requeued := newNotification(t, "requeued@example.com")
+	save(t, repo, requeued)
+
+	filter := domain.RetentionPolicy{Status: domain.StatusFailed, MaxAge: time.Hour}.Filter(time.Now().Add(2 * time.Hour))
+	deleted, err := repo.DeleteByIDs(ctx, []string{exhausted.ID, retrying.ID, requeued.ID}, filter, nil)
+	if err != nil {
+		t.Fatalf("DeleteByIDs: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+
+	if _, err := repo.FindByID(ctx, exhausted.ID); !errors.Is(err, domain.ErrNotificationNotFound) {
+		t.Errorf("exhausted notification: err = %v", err)
+	}
+	find(t, repo, retrying.ID)
+	find(t, repo, requeued.ID)
+
+	// Too young for the policy
+	filter = domain.RetentionPolicy{Status: domain.StatusFailed, MaxAge: time.Hour}.Filter(time.Now())
+	young := newNotification(t, "young@example.com")
+	for i := 0; i < young.MaxRetries; i++ {
+		young.MarkAsFailed("timeout", time.Minute)
+	}
+	save(t, repo, young)
+
+	if deleted, err := repo.DeleteByIDs(ctx, []string{young.ID}, filter, nil); err != nil || deleted != 0 {
+		t.Errorf("DeleteByIDs young = %d, %v, want 0", deleted, err)
+	}
+}
+
+func testDeleteByIDsArchivesDeleted(t *testing.T, repo ports.NotificationRepository) {
+	ctx := context.Background()
+
+	gone := newNotification(t, "gone@example.com")
+	save(t, repo, gone)
+	kept := newNotification(t, "kept@example.com")
+	save(t, repo, kept)
+
+	// An archive that fails keeps every row
+	failed := errors.New("archive unavailable")
+	if _, err := repo.DeleteByIDs(ctx, []string{gone.ID}, domain.NotificationFilter{}, func([]*domain.Notification) error {
+		return failed
+	}); !errors.Is(err, failed) {
+		t.Fatalf("DeleteByIDs err = %v, want %v", err, failed)
+	}
+	find(t, repo, gone.ID)
+
+	pending := domain.StatusPending
+	if err := repo.UpdateStatus(ctx, kept.ID, domain.StatusSent, ""); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	var archived []*domain.Notification
+	deleted, err := repo.DeleteByIDs(ctx, []string{gone.ID, kept.ID}, domain.NotificationFilter{Status: &pending}, func(n []*domain.Notification) error {
+		archived = append(archived, n...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DeleteByIDs: %v", err)
+	}
+	if deleted != 1 || len(archived) != 1 || archived[0].ID != gone.ID {
+		t.Fatalf("deleted %d, archived %v, want only %s", deleted, ids(archived), gone.ID)
+	}
+	if archived[0].Recipient.Email == nil || *archived[0].Recipient.Email != "gone@example.com" {
+		t.Errorf("archived email = %v", archived[0].Recipient.Email)
+	}
+	find(t, repo, kept.ID)
+}
+
+func ids(notifications []*domain.Notification) map[string]bool {
+	set := make(map[string]bool, len(notifications))
+	for _, n := range notifications {
+		set[n.ID] = true
+	}
+	return set
+}
@@ -0,0 +1,117 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+)
+
+// RunTemplateRepository runs the contract against the empty repositories open returns
+func RunTemplateRepository(t *testing.T, open func(t *testing.T) ports.TemplateRepository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo ports.TemplateRepository)
+	}{
+		{"Versions", testTemplateVersions},
+		{"Publish", testTemplatePublish},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := open(t)
+			defer repo.Close()
+			tt.run(t, repo)
+		})
+	}
+}
+
+func createTemplate(t *testing.T, repo ports.TemplateRepository, name, body string) *domain.TemplateVersion {
+	t.Helper()
+
+	v, err := domain.NewTemplateVersion(name, body)
+	if err != nil {
+		t.Fatalf("NewTemplateVersion: %v", err)
+	}
+	if err := repo.CreateVersion(context.Background(), v); err != nil {
+		t.Fatalf("CreateVersion: %v", err)
+	}
+	return v
+}
+
+func testTemplateVersions(t *testing.T, repo ports.TemplateRepository) {
+	ctx := context.Background()
+
+	first := createTemplate(t, repo, "welcome", "v1")
+	second := createTemplate(t, repo, "welcome", "v2")
+	if first.Version != 1 || second.Version != 2 {
+		t.Fatalf("versions = %d, %d, want 1, 2", first.Version, second.Version)
+	}
+
+	if err := second.UpdateBody("v2 edited"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateVersion(ctx, second); err != nil {
+		t.Fatalf("UpdateVersion: %v", err)
+	}
+
+	got, err := repo.FindVersion(ctx, "welcome", 2)
+	if err != nil || got.Body != "v2 edited" {
+		t.Fatalf("FindVersion = %+v, %v", got, err)
+	}
+
+	if err := repo.DeleteVersion(ctx, "welcome", 1); err != nil {
+		t.Fatalf("DeleteVersion: %v", err)
+	}
+	if _, err := repo.FindVersion(ctx, "welcome", 1); !errors.Is(err, domain.ErrTemplateNotFound) {
+		t.Errorf("deleted version: err = %v", err)
+	}
+
+	versions, err := repo.ListVersions(ctx, "welcome")
+	if err != nil || len(versions) != 1 {
+		t.Errorf("ListVersions = %d, %v, want 1", len(versions), err)
+	}
+}
+
+func testTemplatePublish(t *testing.T, repo ports.TemplateRepository) {
+	ctx := context.Background()
+
+	createTemplate(t, repo, "welcome", "v1")
+	createTemplate(t, repo, "welcome", "v2")
+
+	if err := repo.Publish(ctx, "welcome", 1); err != nil {
+		t.Fatalf("Publish 1: %v", err)
+	}
+	if err := repo.Publish(ctx, "welcome", 2); err != nil {
+		t.Fatalf("Publish 2: %v", err)
+	}
+	if err := repo.Publish(ctx, "welcome", 3); !errors.Is(err, domain.ErrTemplateNotFound) {
+		t.Errorf("Publish missing: err = %v", err)
+	}
+
+	published, err := repo.FindPublished(ctx)
+	if err != nil {
+		t.Fatalf("FindPublished: %v", err)
+	}
+	if len(published) != 1 || published[0].Version != 2 || published[0].PublishedAt == nil {
+		t.Fatalf("published = %+v, want only version 2", published)
+	}
+
+	// Published versions are frozen
+	published[0].Body = "changed"
+	if err := repo.UpdateVersion(ctx, published[0]); !errors.Is(err, domain.ErrTemplateNotDraft) {
+		t.Errorf("UpdateVersion published: err = %v", err)
+	}
+
+	if err := repo.Unpublish(ctx, "welcome"); err != nil {
+		t.Fatalf("Unpublish: %v", err)
+	}
+	if published, _ := repo.FindPublished(ctx); len(published) != 0 {
+		t.Errorf("published after Unpublish = %d", len(published))
+	}
+	if err := repo.Unpublish(ctx, "welcome"); !errors.Is(err, domain.ErrTemplateNotFound) {
+		t.Errorf("second Unpublish: err = %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	_ "modernc.org/sqlite"
+)
+
+type SQLiteCampaignRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteCampaignRepository(dbPath string) (ports.CampaignRepository, error) {
+	dsn := fmt.Sprintf("%s?_journal=WAL&_timeout=5000&_fk=true", dbPath)
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	if err := optimizeSQLite(db); err != nil {
+		return nil, fmt.Errorf("failed to optimize db: %w", err)
+	}
+
+	db.SetMaxOpenConns(5)
+	db.SetMaxIdleConns(2)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	if err := migrate(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return &SQLiteCampaignRepository{db: db}, nil
+}
+
+const campaignColumns = `id, name, channel, template, subject, locale, data, audience, rate_per_second,
+	status, scheduled_at, cursor, created_at, updated_at, started_at, completed_at, version`
+
+func scanCampaign(row rowScanner) (*domain.Campaign, error) {
+	var c domain.Campaign
+	var channel, status, createdAt, updatedAt, audienceJSON string
+	var locale, dataJSON, scheduledAt, startedAt, completedAt sql.NullString
+
+	err := row.Scan(
+		&c.ID, &c.Name, &channel, &c.Template, &c.Subject, &locale, &dataJSON, &audienceJSON, &c.RatePerSecond,
+		&status, &scheduledAt, &c.Cursor, &createdAt, &updatedAt, &startedAt, &completedAt, &c.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Channel = domain.NotificationType(channel)
+	c.Status = domain.CampaignStatus(status)
+	c.Locale = locale.String
+
+	if dataJSON.Valid && dataJSON.String != "" {
+		if err := json.Unmarshal([]byte(dataJSON.String), &c.Data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal campaign data: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(audienceJSON), &c.Audience); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal campaign audience: %w", err)
+	}
+
+	if c.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return nil, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	if c.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	if c.ScheduledAt, err = parseNullTime(scheduledAt); err != nil {
+		return nil, fmt.Errorf("failed to parse scheduled_at: %w", err)
+	}
+	if c.StartedAt, err = parseNullTime(startedAt); err != nil {
+		return nil, fmt.Errorf("failed to parse started_at: %w", err)
+	}
+	if c.CompletedAt, err = parseNullTime(completedAt); err != nil {
+		return nil, fmt.Errorf("failed to parse completed_at: %w", err)
+	}
+
+	return &c, nil
+}
+
+func parseNullTime(s sql.NullString) (*time.Time, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func formatNullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Save is an upsert with optimistic locking: an update only applies when the
+// stored version is the one c was loaded with
+func (r *SQLiteCampaignRepository) Save(ctx context.Context, c *domain.Campaign) error {
+	dataJSON, err := json.Marshal(c.Data)
+	if err != nil {
+		return err
+	}
+
+	audienceJSON, err := json.Marshal(c.Audience)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+	INSERT INTO campaigns (`+campaignColumns+`)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(id) DO UPDATE SET
+		status = excluded.status,
+		scheduled_at = excluded.scheduled_at,
+		cursor = excluded.cursor,
+		updated_at = excluded.updated_at,
+		started_at = excluded.started_at,
+		completed_at = excluded.completed_at,
+		version = excluded.version
+	WHERE version = ?
+	`,
+		c.ID, c.Name, string(c.Channel), c.Template, c.Subject, c.Locale, string(dataJSON), string(audienceJSON), c.RatePerSecond,
+		string(c.Status), formatNullTime(c.ScheduledAt), c.Cursor,
+		c.CreatedAt.UTC().Format(time.RFC3339), c.UpdatedAt.UTC().Format(time.RFC3339),
+		formatNullTime(c.StartedAt), formatNullTime(c.CompletedAt), c.Version,
+		c.Version-1,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save campaign: %w", err)
+	}
+
+	return requireAffected(result, fmt.Errorf("%w: %s", domain.ErrCampaignConflict, c.ID))
+}
+
+func (r *SQLiteCampaignRepository) FindByID(ctx context.Context, id string) (*domain.Campaign, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+campaignColumns+` FROM campaigns WHERE id = ?`, id)
+
+	c, err := scanCampaign(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrCampaignNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find campaign: %w", err)
+	}
+	return c, nil
+}
+
+func (r *SQLiteCampaignRepository) List(ctx context.Context) ([]*domain.Campaign, error) {
+	return r.query(ctx, `SELECT `+campaignColumns+` FROM campaigns ORDER BY created_at DESC`)
+}
+
+func (r *SQLiteCampaignRepository) FindActive(ctx context.Context) ([]*domain.Campaign, error) {
+	return r.query(ctx, `SELECT `+campaignColumns+` FROM campaigns WHERE status IN ('SCHEDULED', 'SENDING') ORDER BY created_at ASC`)
+}
+
+func (r *SQLiteCampaignRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Campaign, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	var campaigns []*domain.Campaign
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, c)
+	}
+
+	return campaigns, rows.Err()
+}
+
+func (r *SQLiteCampaignRepository) Close() error {
+	return r.db.Close()
+}
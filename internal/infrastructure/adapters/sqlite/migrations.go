@@ -0,0 +1,498 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Migration is one versioned change to the SQLite schema. Up and Down run in a
+// transaction together with the bookkeeping in schema_migrations.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *sql.Tx) error
+	Down    func(tx *sql.Tx) error
+}
+
+// migrations are applied in order. Databases created before they were tracked
+// went through the same steps ad hoc, so the early ones tolerate finding their
+// change already made.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create_notifications",
+		Up: execAll(`
+		CREATE TABLE IF NOT EXISTS notifications (
+			id TEXT PRIMARY KEY,
+			type TEXT NOT NULL,
+			recipient_id TEXT NOT NULL,
+			recipient_email TEXT,
+			recipient_phone TEXT,
+			recipient_device TEXT,
+			title TEXT NOT NULL,
+			body TEXT,
+			data TEXT, -- JSON data
+			html TEXT,
+			template TEXT,
+			status TEXT NOT NULL,
+			provider_response TEXT,
+			created_at DATETIME NOT NULL,
+			sent_at DATETIME,
+			retry_count INTEGER DEFAULT 0,
+			max_retries INTEGER DEFAULT 3,
+			version INTEGER DEFAULT 1,
+			CHECK (type IN ('EMAIL', 'SMS', 'PUSH', 'IN_APP')),
+			CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'DELIVERED'))
+		)`,
+			"CREATE INDEX IF NOT EXISTS idx_notifications_status ON notifications(status, created_at)",
+			"CREATE INDEX IF NOT EXISTS idx_notifications_recipient ON notifications(recipient_id)",
+			"CREATE INDEX IF NOT EXISTS idx_notifications_retry ON notifications(status, retry_count, created_at) WHERE status = 'FAILED'",
+		),
+		Down: execAll(`DROP TABLE notifications`),
+	},
+	addColumnMigration(2, "is_marketing", "INTEGER DEFAULT 0"),
+	addColumnMigration(3, "attachments", "TEXT"), // JSON array
+	addColumnMigration(4, "locale", "TEXT"),
+	addColumnMigration(5, "template_version", "INTEGER DEFAULT 0"),
+	{
+		Version: 6,
+		Name:    "create_email_templates",
+		Up: execAll(`
+		CREATE TABLE IF NOT EXISTS email_templates (
+			name TEXT NOT NULL,
+			version INTEGER NOT NULL,
+			body TEXT NOT NULL,
+			status TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			published_at DATETIME,
+			PRIMARY KEY (name, version),
+			CHECK (status IN ('DRAFT', 'PUBLISHED', 'ARCHIVED'))
+		)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_email_templates_published
+			ON email_templates(name) WHERE status = 'PUBLISHED'`,
+		),
+		Down: execAll(`DROP TABLE email_templates`),
+	},
+	addColumnMigration(7, "resent_from", "TEXT"),
+	addStatusMigration(8, "CANCELLED"),
+	{
+		Version: 9,
+		Name:    "add_campaign_id",
+		Up: func(tx *sql.Tx) error {
+			if err := addColumnIfMissing(tx, "campaign_id", "TEXT"); err != nil {
+				return err
+			}
+			_, err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_notifications_campaign ON notifications(campaign_id, status) WHERE campaign_id IS NOT NULL")
+			return err
+		},
+		Down: execAll(
+			"DROP INDEX idx_notifications_campaign",
+			"ALTER TABLE notifications DROP COLUMN campaign_id",
+		),
+	},
+	{
+		Version: 10,
+		Name:    "create_campaigns",
+		Up: execAll(`
+		CREATE TABLE IF NOT EXISTS campaigns (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			channel TEXT NOT NULL,
+			template TEXT NOT NULL,
+			subject TEXT NOT NULL,
+			locale TEXT,
+			data TEXT,        -- JSON object
+			audience TEXT NOT NULL, -- JSON object
+			rate_per_second INTEGER NOT NULL,
+			status TEXT NOT NULL,
+			scheduled_at DATETIME,
+			cursor INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			started_at DATETIME,
+			completed_at DATETIME,
+			version INTEGER NOT NULL,
+			CHECK (status IN ('DRAFT', 'SCHEDULED', 'SENDING', 'PAUSED', 'COMPLETED', 'CANCELLED'))
+		)`,
+			"CREATE INDEX IF NOT EXISTS idx_campaigns_status ON campaigns(status, created_at)",
+		),
+		Down: execAll(`DROP TABLE campaigns`),
+	},
+	addColumnMigration(11, "recipient_spec", "TEXT"),
+	addColumnMigration(12, "next_attempt_at", "DATETIME"),
+	addStatusMigration(13, "REJECTED"),
+	{
+		Version: 14,
+		Name:    "add_due_index",
+		Up:      execAll("CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(next_attempt_at) WHERE status IN ('PENDING', 'FAILED')"),
+		Down:    execAll("DROP INDEX idx_notifications_due"),
+	},
+	{
+		Version: 15,
+		Name:    "add_encryption_columns",
+		Up: func(tx *sql.Tx) error {
+			for _, column := range []string{"key_id", "recipient_email_bidx", "recipient_phone_bidx"} {
+				if err := addColumnIfMissing(tx, column, "TEXT"); err != nil {
+					return err
+				}
+			}
+			return execAll(
+				"CREATE INDEX IF NOT EXISTS idx_notifications_email_bidx ON notifications(recipient_email_bidx)",
+				"CREATE INDEX IF NOT EXISTS idx_notifications_phone_bidx ON notifications(recipient_phone_bidx)",
+			)(tx)
+		},
+		// Encrypted rows would be unreadable without their key IDs
+		Down: func(tx *sql.Tx) error {
+			var encrypted int
+			if err := tx.QueryRow(`SELECT COUNT(*) FROM notifications WHERE key_id IS NOT NULL`).Scan(&encrypted); err != nil {
+				return err
+			}
+			if encrypted > 0 {
+				return fmt.Errorf("%d notifications are still encrypted", encrypted)
+			}
+			return execAll(
+				"DROP INDEX idx_notifications_email_bidx",
+				"DROP INDEX idx_notifications_phone_bidx",
+				"ALTER TABLE notifications DROP COLUMN key_id",
+				"ALTER TABLE notifications DROP COLUMN recipient_email_bidx",
+				"ALTER TABLE notifications DROP COLUMN recipient_phone_bidx",
+			)(tx)
+		},
+	},
+	{
+		// content_sealed marks rows whose title and attachments are encrypted
+		// too; rows encrypted before have them in plaintext
+		Version: 16,
+		Name:    "add_content_sealed",
+		Up: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "content_sealed", "INTEGER NOT NULL DEFAULT 0")
+		},
+		Down: func(tx *sql.Tx) error {
+			var sealed int
+			if err := tx.QueryRow(`SELECT COUNT(*) FROM notifications WHERE content_sealed = 1`).Scan(&sealed); err != nil {
+				return err
+			}
+			if sealed > 0 {
+				return fmt.Errorf("%d notifications have an encrypted title", sealed)
+			}
+			return execAll("ALTER TABLE notifications DROP COLUMN content_sealed")(tx)
+		},
+	},
+	addColumnMigration(17, "request_hash", "TEXT"),
+}
+
+func execAll(statements ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, stmt := range statements {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func addColumnMigration(version int, column, definition string) Migration {
+	return Migration{
+		Version: version,
+		Name:    "add_" + column,
+		Up: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, column, definition)
+		},
+		Down: execAll(fmt.Sprintf("ALTER TABLE notifications DROP COLUMN %s", column)),
+	}
+}
+
+// addStatusMigration allows a new notification status. Reverting it fails while
+// notifications still have that status.
+func addStatusMigration(version int, status string) Migration {
+	return Migration{
+		Version: version,
+		Name:    "add_" + strings.ToLower(status) + "_status",
+		Up: func(tx *sql.Tx) error {
+			return changeStatusCheck(tx, func(statuses []string) []string {
+				if slices.Contains(statuses, status) {
+					return statuses
+				}
+				return append(statuses, status)
+			})
+		},
+		Down: func(tx *sql.Tx) error {
+			return changeStatusCheck(tx, func(statuses []string) []string {
+				return slices.DeleteFunc(statuses, func(s string) bool { return s == status })
+			})
+		},
+	}
+}
+
+// addColumnIfMissing adds a column to the notifications table on existing databases
+func addColumnIfMissing(tx *sql.Tx, column, definition string) error {
+	var count int
+	err := tx.QueryRow(`
+		SELECT COUNT(*) FROM pragma_table_info('notifications') WHERE name = ?
+	`, column).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE notifications ADD COLUMN %s %s`, column, definition))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var statusCheckPattern = regexp.MustCompile(`CHECK \(status IN \(([^)]*)\)\)`)
+
+// changeStatusCheck rewrites the notifications status CHECK constraint. SQLite
+// can't alter a constraint in place, so the table is rebuilt when it changes.
+func changeStatusCheck(tx *sql.Tx, change func(statuses []string) []string) error {
+	ddl, err := tableDDL(tx, "notifications")
+	if err != nil {
+		return err
+	}
+
+	match := statusCheckPattern.FindStringSubmatch(ddl)
+	if match == nil {
+		return fmt.Errorf("unexpected notifications schema: %s", ddl)
+	}
+
+	var statuses []string
+	for _, s := range strings.Split(match[1], ",") {
+		statuses = append(statuses, strings.Trim(strings.TrimSpace(s), "'"))
+	}
+
+	changed := change(slices.Clone(statuses))
+	if slices.Equal(changed, statuses) {
+		return nil
+	}
+
+	quoted := make([]string, len(changed))
+	for i, s := range changed {
+		quoted[i] = "'" + s + "'"
+	}
+	check := fmt.Sprintf("CHECK (status IN (%s))", strings.Join(quoted, ", "))
+
+	return rebuildTable(tx, "notifications", strings.Replace(ddl, match[0], check, 1))
+}
+
+func tableDDL(tx *sql.Tx, table string) (string, error) {
+	var ddl string
+	err := tx.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&ddl)
+	return ddl, err
+}
+
+// rebuildTable recreates table from ddl, which must keep its columns in order,
+// and copies the rows and indexes over
+func rebuildTable(tx *sql.Tx, table, ddl string) error {
+	rows, err := tx.Query(`SELECT sql FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL`, table)
+	if err != nil {
+		return err
+	}
+	var indexes []string
+	for rows.Next() {
+		var index string
+		if err := rows.Scan(&index); err != nil {
+			rows.Close()
+			return err
+		}
+		indexes = append(indexes, index)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rebuild := table + "_rebuild"
+	statements := []string{
+		strings.Replace(ddl, table, rebuild, 1),
+		fmt.Sprintf(`INSERT INTO %s SELECT * FROM %s`, rebuild, table),
+		fmt.Sprintf(`DROP TABLE %s`, table),
+		fmt.Sprintf(`ALTER TABLE %s RENAME TO %s`, rebuild, table),
+	}
+	statements = append(statements, indexes...)
+
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator applies and reverts the SQLite schema migrations
+type Migrator struct {
+	db *sql.DB
+}
+
+// NewMigrator opens the database at dbPath for migration commands
+func NewMigrator(dbPath string) (*Migrator, error) {
+	dsn := fmt.Sprintf("%s?_journal=WAL&_timeout=5000&_fk=true", dbPath)
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	if err := optimizeSQLite(db); err != nil {
+		return nil, fmt.Errorf("failed to optimize db: %w", err)
+	}
+
+	return &Migrator{db: db}, nil
+}
+
+// migrate brings the schema up to date when a repository opens the database
+func migrate(db *sql.DB) error {
+	return (&Migrator{db: db}).Up(context.Background())
+}
+
+func (m *Migrator) ensureSchemaTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at DATETIME NOT NULL
+	)`)
+	return err
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	if err := m.ensureSchemaTable(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt string
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		t, err := time.Parse(time.RFC3339, appliedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse applied_at: %w", err)
+		}
+		applied[version] = t
+	}
+
+	return applied, rows.Err()
+}
+
+// Up applies every pending migration in order, each in its own transaction
+func (m *Migrator) Up(ctx context.Context) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+				migration.Version, migration.Name, time.Now().UTC().Format(time.RFC3339))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d %s failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Down reverts the applied migrations above target, newest first
+func (m *Migrator) Down(ctx context.Context, target int) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if migration.Version <= target {
+			break
+		}
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, migration.Version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("reverting migration %d %s failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Status lists every known migration and when it was applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if at, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (m *Migrator) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
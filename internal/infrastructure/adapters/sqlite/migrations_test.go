@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newTestMigrator(t *testing.T) *Migrator {
+	t.Helper()
+
+	m, err := NewMigrator(filepath.Join(t.TempDir(), "notifications.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { m.Close() })
+	return m
+}
+
+// schema describes the tables, columns, indexes and allowed statuses, ignoring
+// how the DDL text was written
+func schema(t *testing.T, db *sql.DB) []string {
+	t.Helper()
+
+	rows, err := db.Query(`
+		SELECT m.type, m.name, m.tbl_name, COALESCE(m.sql, '')
+		FROM sqlite_master m
+		WHERE m.name NOT LIKE 'sqlite_%' AND m.name != 'schema_migrations'
+		ORDER BY m.type, m.name
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type object struct{ kind, name, table, ddl string }
+	var objects []object
+	for rows.Next() {
+		var o object
+		if err := rows.Scan(&o.kind, &o.name, &o.table, &o.ddl); err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, o)
+	}
+	rows.Close()
+
+	var out []string
+	for _, o := range objects {
+		switch o.kind {
+		case "table":
+			cols, err := db.Query(`SELECT name, type, "notnull", COALESCE(dflt_value, ''), pk FROM pragma_table_info(?) ORDER BY name`, o.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for cols.Next() {
+				var name, typ, dflt string
+				var notNull, pk int
+				if err := cols.Scan(&name, &typ, &notNull, &dflt, &pk); err != nil {
+					t.Fatal(err)
+				}
+				out = append(out, fmt.Sprintf("column %s.%s %s notnull=%d default=%s pk=%d", o.name, name, typ, notNull, dflt, pk))
+			}
+			cols.Close()
+
+			if match := statusCheckPattern.FindStringSubmatch(o.ddl); match != nil {
+				statuses := strings.Split(strings.ReplaceAll(match[1], " ", ""), ",")
+				slices.Sort(statuses)
+				out = append(out, fmt.Sprintf("check %s.status %s", o.name, strings.Join(statuses, ",")))
+			}
+		case "index":
+			out = append(out, fmt.Sprintf("index %s on %s", o.name, strings.Join(strings.Fields(o.ddl), " ")))
+		}
+	}
+	return out
+}
+
+func TestMigrationsAreOrdered(t *testing.T) {
+	for i, m := range migrations {
+		if m.Up == nil || m.Down == nil || m.Name == "" {
+			t.Errorf("migration %d is incomplete", m.Version)
+		}
+		if i > 0 && m.Version <= migrations[i-1].Version {
+			t.Errorf("migration %d comes after %d", m.Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestMigrationsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMigrator(t)
+
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	latest := schema(t, m.db)
+
+	// Up again is a no-op
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("second Up: %v", err)
+	}
+
+	// Reverting each migration in turn and reapplying the rest gets back to the same schema
+	for i := len(migrations) - 1; i >= 0; i-- {
+		target := 0
+		if i > 0 {
+			target = migrations[i-1].Version
+		}
+
+		t.Run(fmt.Sprintf("down to %d", target), func(t *testing.T) {
+			if err := m.Down(ctx, target); err != nil {
+				t.Fatalf("Down(%d): %v", target, err)
+			}
+
+			statuses, err := m.Status(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range statuses {
+				if applied := s.AppliedAt != nil; applied != (s.Version <= target) {
+					t.Fatalf("migration %d applied = %v after Down(%d)", s.Version, applied, target)
+				}
+			}
+
+			if err := m.Up(ctx); err != nil {
+				t.Fatalf("Up from %d: %v", target, err)
+			}
+			if got := schema(t, m.db); !slices.Equal(got, latest) {
+				t.Fatalf("schema after Down(%d) and Up differs:\n%s\nwant:\n%s", target, strings.Join(got, "\n"), strings.Join(latest, "\n"))
+			}
+		})
+	}
+}
+
+func TestMigrationsDownRefusesToLoseData(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		insert string
+		target int
+	}{
+		{
+			name:   "cancelled notifications",
+			insert: `UPDATE notifications SET status = 'CANCELLED'`,
+			target: 7,
+		},
+		{
+			name:   "encrypted titles",
+			insert: `UPDATE notifications SET content_sealed = 1`,
+			target: 15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMigrator(t)
+			if err := m.Up(ctx); err != nil {
+				t.Fatalf("Up: %v", err)
+			}
+
+			if _, err := m.db.Exec(`
+				INSERT INTO notifications (id, type, recipient_id, title, status, created_at, retry_count, max_retries, version)
+				VALUES ('n1', 'EMAIL', 'u1', 'Hello', 'PENDING', '2026-01-01T00:00:00Z', 0, 3, 1)
+			`); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := m.db.Exec(tt.insert); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := m.Down(ctx, tt.target); err == nil {
+				t.Fatalf("Down(%d) succeeded", tt.target)
+			}
+
+			var count int
+			if err := m.db.QueryRow(`SELECT COUNT(*) FROM notifications`).Scan(&count); err != nil || count != 1 {
+				t.Fatalf("notifications = %d, %v after the refused Down", count, err)
+			}
+		})
+	}
+}
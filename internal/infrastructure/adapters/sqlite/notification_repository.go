@@ -10,16 +10,20 @@ import (
 
 	"github.com/commitshark/notification-svc/internal/domain"
 	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/encryption"
 	"github.com/commitshark/notification-svc/internal/utils"
 	"github.com/google/uuid"
 	_ "modernc.org/sqlite"
 )
 
+// SQLiteNotificationRepository encrypts recipient contact details and message
+// content with keyring; a nil keyring stores them in plaintext.
 type SQLiteNotificationRepository struct {
-	db *sql.DB
+	db      *sql.DB
+	keyring *encryption.Keyring
 }
 
-func NewSQLiteNotificationRepository(dbPath string) (ports.NotificationRepository, error) {
+func NewSQLiteNotificationRepository(dbPath string, keyring *encryption.Keyring) (ports.NotificationRepository, error) {
 	// SQLite with WAL mode for better concurrency
 	dsn := fmt.Sprintf("%s?_journal=WAL&_timeout=5000&_fk=true", dbPath)
 
@@ -41,12 +45,11 @@ func NewSQLiteNotificationRepository(dbPath string) (ports.NotificationRepositor
 		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
 	}
 
-	// Create tables
-	if err := createTables(db); err != nil {
-		return nil, fmt.Errorf("failed to create tables: %w", err)
+	if err := migrate(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
-	return &SQLiteNotificationRepository{db: db}, nil
+	return &SQLiteNotificationRepository{db: db, keyring: keyring}, nil
 }
 
 // Add these to SQLite setup
@@ -62,111 +65,115 @@ func optimizeSQLite(db *sql.DB) error {
 	return err
 }
 
-func createTables(db *sql.DB) error {
-	// Notifications table
-	notificationTable := `
-	CREATE TABLE IF NOT EXISTS notifications (
-		id TEXT PRIMARY KEY,
-		type TEXT NOT NULL,
-		recipient_id TEXT NOT NULL,
-		recipient_email TEXT,
-		recipient_phone TEXT,
-		recipient_device TEXT,
-		title TEXT NOT NULL,
-		body TEXT,
-		data TEXT, -- JSON data
-		html TEXT,
-		template TEXT,
-		status TEXT NOT NULL,
-		provider_response TEXT,
-		created_at DATETIME NOT NULL,
-		sent_at DATETIME,
-		retry_count INTEGER DEFAULT 0,
-		max_retries INTEGER DEFAULT 3,
-		version INTEGER DEFAULT 1,
-		CHECK (type IN ('EMAIL', 'SMS', 'PUSH', 'IN_APP')),
-		CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'DELIVERED'))
-	);
-	`
-
-	// Indexes for query performance
-	indexes := []string{
-		"CREATE INDEX IF NOT EXISTS idx_notifications_status ON notifications(status, created_at)",
-		"CREATE INDEX IF NOT EXISTS idx_notifications_recipient ON notifications(recipient_id)",
-		"CREATE INDEX IF NOT EXISTS idx_notifications_retry ON notifications(status, retry_count, created_at) WHERE status = 'FAILED'",
-	}
-
-	tx, err := db.Begin()
-	if err != nil {
-		return err
+func unmarshalAttachments(attachmentsJSON *string) ([]domain.Attachment, error) {
+	if attachmentsJSON == nil || *attachmentsJSON == "" {
+		return nil, nil
 	}
-	defer tx.Rollback()
 
-	if _, err := tx.Exec(notificationTable); err != nil {
-		return fmt.Errorf("failed to create notifications table: %w", err)
-	}
-
-	for _, index := range indexes {
-		if _, err := tx.Exec(index); err != nil {
-			return fmt.Errorf("failed to create index: %w", err)
-		}
+	var attachments []domain.Attachment
+	if err := json.Unmarshal([]byte(*attachmentsJSON), &attachments); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attachments: %w", err)
 	}
 
-	// Migrate existing table if column doesn't exist
-	if err := migrateAddIsMarketing(tx); err != nil {
-		return fmt.Errorf("failed to migrate is_marketing: %w", err)
-	}
-
-	return tx.Commit()
+	return attachments, nil
 }
 
-func migrateAddIsMarketing(tx *sql.Tx) error {
-	// SQLite has no IF NOT EXISTS for columns, so check information_schema equivalent
-	var count int
-	err := tx.QueryRow(`
-		SELECT COUNT(*) FROM pragma_table_info('notifications') WHERE name = 'is_marketing'
-	`).Scan(&count)
-	if err != nil {
-		return err
-	}
-
-	if count == 0 {
-		_, err = tx.Exec(`ALTER TABLE notifications ADD COLUMN is_marketing INTEGER DEFAULT 0`)
-		if err != nil {
-			return err
-		}
-	}
+// notificationColumns is the column list scanNotification expects
+const notificationColumns = `
+	id,
+	type,
+	recipient_id,
+	recipient_email,
+	recipient_phone,
+	recipient_device,
+	title,
+	body,
+	data,
+	html,
+	template,
+	status,
+	provider_response,
+	created_at,
+	sent_at,
+	retry_count,
+	max_retries,
+	is_marketing,
+	version,
+	attachments,
+	locale,
+	template_version,
+	resent_from,
+	campaign_id,
+	recipient_spec,
+	next_attempt_at,
+	key_id,
+	content_sealed,
+	request_hash
+`
 
-	return nil
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
 }
 
-func scanNotification(rows *sql.Rows) (*domain.Notification, error) {
+func scanNotification(rows rowScanner, keyring *encryption.Keyring) (*domain.Notification, error) {
 	var n domain.Notification
 	var recipientID string
 	var recipientEmail, recipientPhone, recipientDevice, html, template sql.NullString
 	var title, statusStr, typeStr string
-	var body, dataJSON sql.NullString
+	var body, dataJSON, attachmentsJSON, locale sql.NullString
 	var providerResponse sql.NullString
 	var createdAtStr string
 	var sentAtStr sql.NullString
+	var templateVersion sql.NullInt64
+	var resentFrom, campaignID, recipientSpec sql.NullString
+	var nextAttemptAtStr, keyID sql.NullString
+	var contentSealed bool
+	var requestHash sql.NullString
 
 	err := rows.Scan(
 		&n.ID, &typeStr, &recipientID, &recipientEmail, &recipientPhone, &recipientDevice,
 		&title, &body, &dataJSON, &html, &template, &statusStr, &providerResponse,
 		&createdAtStr, &sentAtStr, &n.RetryCount, &n.MaxRetries, &n.IsMarketing, &n.Version,
+		&attachmentsJSON, &locale, &templateVersion, &resentFrom, &campaignID, &recipientSpec,
+		&nextAttemptAtStr, &keyID, &contentSealed, &requestHash,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	open := keyring.Open
+	if !contentSealed {
+		open = keyring.OpenLegacy
+	}
+
+	fields, err := open(n.ID, keyID.String, encryption.Fields{
+		Email:       utils.SqlNullableString(recipientEmail),
+		Phone:       utils.SqlNullableString(recipientPhone),
+		Device:      utils.SqlNullableString(recipientDevice),
+		Title:       &title,
+		Body:        utils.SqlNullableString(body),
+		Data:        utils.SqlNullableString(dataJSON),
+		HTML:        utils.SqlNullableString(html),
+		Attachments: utils.SqlNullableString(attachmentsJSON),
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse JSON data
 	var data map[string]interface{}
-	if dataJSON.Valid && dataJSON.String != "" {
-		if err := json.Unmarshal([]byte(dataJSON.String), &data); err != nil {
+	if fields.Data != nil && *fields.Data != "" {
+		if err := json.Unmarshal([]byte(*fields.Data), &data); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal data: %w", err)
 		}
 	}
 
+	attachments, err := unmarshalAttachments(fields.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse timestamps
 	createdAt, err := time.Parse(time.RFC3339, createdAtStr)
 	if err != nil {
@@ -182,20 +189,32 @@ func scanNotification(rows *sql.Rows) (*domain.Notification, error) {
 		sentAt = &t
 	}
 
+	var nextAttemptAt *time.Time
+	if nextAttemptAtStr.Valid {
+		t, err := time.Parse(time.RFC3339, nextAttemptAtStr.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse next_attempt_at: %w", err)
+		}
+		nextAttemptAt = &t
+	}
+
 	// Build domain objects
 	recipient := domain.Recipient{
 		ID:       recipientID,
-		Email:    utils.SqlNullableString(recipientEmail),
-		Phone:    utils.SqlNullableString(recipientPhone),
-		DeviceID: utils.SqlNullableString(recipientDevice),
+		Email:    fields.Email,
+		Phone:    fields.Phone,
+		DeviceID: fields.Device,
+		Spec:     utils.SqlNullableString(recipientSpec),
 	}
 
 	content := domain.Content{
-		Title:    title,
-		Body:     utils.SqlNullableString(body),
-		Data:     &data,
-		HTML:     utils.SqlNullableString(html),
-		Template: utils.SqlNullableString(template),
+		Title:       *fields.Title,
+		Body:        fields.Body,
+		Data:        &data,
+		HTML:        fields.HTML,
+		Template:    utils.SqlNullableString(template),
+		Attachments: attachments,
+		Locale:      locale.String,
 	}
 
 	n.Type = domain.NotificationType(typeStr)
@@ -205,6 +224,11 @@ func scanNotification(rows *sql.Rows) (*domain.Notification, error) {
 	n.ProviderResponse = providerResponse.String
 	n.CreatedAt = createdAt
 	n.SentAt = sentAt
+	n.TemplateVersion = int(templateVersion.Int64)
+	n.ResentFrom = utils.SqlNullableString(resentFrom)
+	n.CampaignID = utils.SqlNullableString(campaignID)
+	n.NextAttemptAt = nextAttemptAt
+	n.RequestHash = utils.SqlNullableString(requestHash)
 
 	return &n, nil
 }
@@ -215,13 +239,18 @@ func (r *SQLiteNotificationRepository) Save(ctx context.Context, notification *d
 INSERT INTO notifications (
     id, type, recipient_id, recipient_email, recipient_phone,
     recipient_device, title, body, data, status, provider_response,
-    created_at, sent_at, retry_count, max_retries, html, template, is_marketing, version
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    created_at, sent_at, retry_count, max_retries, html, template, is_marketing, version,
+    attachments, locale, template_version, resent_from, campaign_id,
+    recipient_spec, next_attempt_at, key_id, recipient_email_bidx, recipient_phone_bidx,
+    content_sealed, request_hash
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE SET
     status = excluded.status,
     provider_response = excluded.provider_response,
     sent_at = excluded.sent_at,
     retry_count = excluded.retry_count,
+    template_version = excluded.template_version,
+    next_attempt_at = excluded.next_attempt_at,
     version = version + 1
 WHERE version = ?
 `
@@ -231,6 +260,11 @@ WHERE version = ?
 		sentAt = notification.SentAt.Format(time.RFC3339)
 	}
 
+	var nextAttemptAt interface{}
+	if notification.NextAttemptAt != nil {
+		nextAttemptAt = formatAttemptTime(*notification.NextAttemptAt)
+	}
+
 	var dataJSON string
 	if notification.Content.Data != nil {
 		b, err := json.Marshal(notification.Content.Data)
@@ -240,26 +274,62 @@ WHERE version = ?
 		dataJSON = string(b)
 	}
 
+	var attachmentsJSON *string
+	if len(notification.Content.Attachments) > 0 {
+		b, err := json.Marshal(notification.Content.Attachments)
+		if err != nil {
+			return err
+		}
+		encoded := string(b)
+		attachmentsJSON = &encoded
+	}
+
+	sealed, err := r.keyring.Seal(notification.ID, encryption.Fields{
+		Email:       notification.Recipient.Email,
+		Phone:       notification.Recipient.Phone,
+		Device:      notification.Recipient.DeviceID,
+		Title:       &notification.Content.Title,
+		Body:        notification.Content.Body,
+		Data:        &dataJSON,
+		HTML:        notification.Content.HTML,
+		Attachments: attachmentsJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encrypt notification: %w", err)
+	}
+
 	args := []interface{}{
 		notification.ID,
 		string(notification.Type),
 		notification.Recipient.ID,
-		notification.Recipient.Email,
-		notification.Recipient.Phone,
-		notification.Recipient.DeviceID,
-		notification.Content.Title,
-		notification.Content.Body,
-		string(dataJSON),
+		sealed.Email,
+		sealed.Phone,
+		sealed.Device,
+		sealed.Title,
+		sealed.Body,
+		sealed.Data,
 		string(notification.Status),
 		notification.ProviderResponse,
 		notification.CreatedAt.Format(time.RFC3339),
 		sentAt,
 		notification.RetryCount,
 		notification.MaxRetries,
-		notification.Content.HTML,
+		sealed.HTML,
 		notification.Content.Template,
 		notification.IsMarketing,
 		notification.Version,
+		sealed.Attachments,
+		notification.Content.Locale,
+		notification.TemplateVersion,
+		notification.ResentFrom,
+		notification.CampaignID,
+		notification.Recipient.Spec,
+		nextAttemptAt,
+		sealed.KeyID,
+		sealed.EmailIndex,
+		sealed.PhoneIndex,
+		sealed.KeyID != nil,
+		notification.RequestHash,
 		notification.Version - 1, // For optimistic locking
 	}
 
@@ -273,109 +343,24 @@ WHERE version = ?
 		return err
 	}
 	if rows == 0 {
-		return fmt.Errorf("optimistic locking conflict for notification %s", notification.ID)
+		return fmt.Errorf("%w for notification %s", domain.ErrNotificationConflict, notification.ID)
 	}
 
 	return nil
 }
 
 func (r *SQLiteNotificationRepository) FindByID(ctx context.Context, id string) (*domain.Notification, error) {
-	query := `
-	SELECT 
-		id,
-		type,
-		recipient_id,
-		recipient_email,
-		recipient_phone,
-		recipient_device,
-		title,
-		body,
-		data,
-		html,
-		template,
-		status,
-		provider_response,
-		created_at,
-		sent_at,
-		retry_count,
-		max_retries,
-		is_marketing,
-		version
-	FROM notifications 
-	WHERE id = ?
-	`
-
-	row := r.db.QueryRowContext(ctx, query, id)
-
-	var n domain.Notification
-	var recipientID string
-	var recipientEmail, recipientPhone, recipientDevice, html, template sql.NullString
-	var title, statusStr, typeStr string
-	var body, dataJSON sql.NullString
-	var providerResponse sql.NullString
-	var createdAtStr string
-	var sentAtStr sql.NullString
-	var data map[string]interface{}
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE id = ?`
 
-	err := row.Scan(
-		&n.ID, &typeStr, &recipientID, &recipientEmail, &recipientPhone, &recipientDevice,
-		&title, &body, &dataJSON, &html, &template, &statusStr, &providerResponse,
-		&createdAtStr, &sentAtStr, &n.RetryCount, &n.MaxRetries, &n.IsMarketing, &n.Version,
-	)
+	n, err := scanNotification(r.db.QueryRowContext(ctx, query, id), r.keyring)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("notification not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", domain.ErrNotificationNotFound, id)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan notification: %w", err)
 	}
 
-	// Parse JSON data
-	if dataJSON.Valid && dataJSON.String != "" {
-		if err := json.Unmarshal([]byte(dataJSON.String), &data); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal data: %w", err)
-		}
-	}
-
-	// Parse timestamps
-	createdAt, err := time.Parse(time.RFC3339, createdAtStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse created_at: %w", err)
-	}
-
-	var sentAt *time.Time
-	if sentAtStr.Valid {
-		t, err := time.Parse(time.RFC3339, sentAtStr.String)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse sent_at: %w", err)
-		}
-		sentAt = &t
-	}
-
-	// Build domain objects
-	recipient := domain.Recipient{
-		ID:       recipientID,
-		Email:    utils.SqlNullableString(recipientEmail),
-		Phone:    utils.SqlNullableString(recipientPhone),
-		DeviceID: utils.SqlNullableString(recipientDevice),
-	}
-
-	content := domain.Content{
-		Title:    title,
-		Body:     utils.SqlNullableString(body),
-		Data:     &data,
-		HTML:     utils.SqlNullableString(html),
-		Template: utils.SqlNullableString(template),
-	}
-
-	n.Type = domain.NotificationType(typeStr)
-	n.Status = domain.NotificationStatus(statusStr)
-	n.Recipient = recipient
-	n.Content = content
-	n.ProviderResponse = providerResponse.String
-	n.CreatedAt = createdAt
-	n.SentAt = sentAt
-
-	return &n, nil
+	return n, nil
 }
 
 func (r *SQLiteNotificationRepository) FindPending(ctx context.Context, limit int) ([]*domain.Notification, error) {
@@ -383,11 +368,12 @@ func (r *SQLiteNotificationRepository) FindPending(ctx context.Context, limit in
 	SELECT id FROM notifications 
 	WHERE status IN ('PENDING', 'FAILED') 
 		AND retry_count < max_retries
+		AND (next_attempt_at IS NULL OR next_attempt_at <= ?)
 	ORDER BY created_at ASC
 	LIMIT ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, limit)
+	rows, err := r.db.QueryContext(ctx, query, formatAttemptTime(time.Now()), limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query pending notifications: %w", err)
 	}
@@ -426,7 +412,104 @@ func (r *SQLiteNotificationRepository) PaginatedList(ctx context.Context, page i
     `
 	countQuery := `SELECT COUNT(*) ` + baseQuery
 
-	// Build conditions and args
+	whereClause, args := filterConditions(filter, r.keyring)
+
+	// Get total count
+	var total int
+	countQuery += whereClause
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	if total == 0 {
+		return []*domain.Notification{}, 0, nil
+	}
+
+	// Build data query
+	dataQuery := `
+        SELECT ` + notificationColumns + `
+    ` + baseQuery + whereClause + `
+        ORDER BY created_at DESC
+        LIMIT ? OFFSET ?
+    `
+
+	// Add pagination args
+	args = append(args, pageSize, offset)
+
+	// Execute query
+	rows, err := r.db.QueryContext(ctx, dataQuery, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	// Scan results
+	notifications := make([]*domain.Notification, 0)
+	for rows.Next() {
+		n, err := scanNotification(rows, r.keyring)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return notifications, total, nil
+}
+
+// FindIDs returns the ids of every notification matching filter, oldest first
+func (r *SQLiteNotificationRepository) FindIDs(ctx context.Context, filter domain.NotificationFilter) ([]string, error) {
+	whereClause, args := filterConditions(filter, r.keyring)
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM notifications WHERE 1=1`+whereClause+` ORDER BY created_at ASC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// CountByStatus counts the notifications matching filter per status
+func (r *SQLiteNotificationRepository) CountByStatus(ctx context.Context, filter domain.NotificationFilter) (map[domain.NotificationStatus]int, error) {
+	whereClause, args := filterConditions(filter, r.keyring)
+
+	rows, err := r.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM notifications WHERE 1=1`+whereClause+` GROUP BY status`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[domain.NotificationStatus]int{}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[domain.NotificationStatus(status)] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// filterConditions builds the " AND ..." clause shared by listing and bulk
+// operations. Encrypted emails and phones are matched through their blind
+// indexes, rows still in plaintext through the columns themselves.
+func filterConditions(filter domain.NotificationFilter, keyring *encryption.Keyring) (string, []interface{}) {
 	conditions := []string{}
 	args := []interface{}{}
 
@@ -444,8 +527,13 @@ func (r *SQLiteNotificationRepository) PaginatedList(ctx context.Context, page i
 
 	if filter.Query != "" {
 		q := "%" + strings.ToLower(filter.Query) + "%"
-		conditions = append(conditions, "(LOWER(title) LIKE ? OR LOWER(recipient_email) LIKE ?)")
-		args = append(args, q, q)
+		if keyring != nil {
+			conditions = append(conditions, "((content_sealed = 0 AND LOWER(title) LIKE ?) OR recipient_email_bidx = ? OR (key_id IS NULL AND LOWER(recipient_email) LIKE ?))")
+			args = append(args, q, keyring.BlindIndex(filter.Query), q)
+		} else {
+			conditions = append(conditions, "(LOWER(title) LIKE ? OR LOWER(recipient_email) LIKE ?)")
+			args = append(args, q, q)
+		}
 
 		// If it's a valid UUID, match exactly
 		if _, err := uuid.Parse(filter.Query); err == nil {
@@ -462,76 +550,47 @@ func (r *SQLiteNotificationRepository) PaginatedList(ctx context.Context, page i
 		args = append(args, filter.IsMarketing)
 	}
 
-	// Build WHERE clause
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = " AND " + strings.Join(conditions, " AND ")
+	if filter.Template != nil && *filter.Template != "" {
+		conditions = append(conditions, "template = ?")
+		args = append(args, *filter.Template)
 	}
 
-	// Get total count
-	var total int
-	countQuery += whereClause
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	if filter.Recipient != "" {
+		if keyring != nil {
+			index := keyring.BlindIndex(filter.Recipient)
+			conditions = append(conditions, `(recipient_id = ? OR recipient_email_bidx = ? OR recipient_phone_bidx = ?
+				OR (key_id IS NULL AND (LOWER(recipient_email) = ? OR recipient_phone = ?)))`)
+			args = append(args, filter.Recipient, index, index, strings.ToLower(filter.Recipient), filter.Recipient)
+		} else {
+			conditions = append(conditions, "(recipient_id = ? OR LOWER(recipient_email) = ? OR recipient_phone = ?)")
+			args = append(args, filter.Recipient, strings.ToLower(filter.Recipient), filter.Recipient)
+		}
 	}
 
-	if total == 0 {
-		return []*domain.Notification{}, 0, nil
+	// created_at keeps the offset it was written with, compare normalized UTC values
+	if filter.CreatedAfter != nil {
+		conditions = append(conditions, "datetime(created_at) >= datetime(?)")
+		args = append(args, filter.CreatedAfter.UTC().Format(time.RFC3339))
 	}
 
-	// Build data query
-	dataQuery := `
-        SELECT 
-            id,
-            type,
-            recipient_id,
-            recipient_email,
-            recipient_phone,
-            recipient_device,
-            title,
-            body,
-            data,
-            html,
-            template,
-            status,
-            provider_response,
-            created_at,
-            sent_at,
-            retry_count,
-            max_retries,
-            is_marketing,
-            version
-    ` + baseQuery + whereClause + `
-        ORDER BY created_at DESC
-        LIMIT ? OFFSET ?
-    `
-
-	// Add pagination args
-	args = append(args, pageSize, offset)
-
-	// Execute query
-	rows, err := r.db.QueryContext(ctx, dataQuery, args...)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query notifications: %w", err)
+	if filter.CreatedBefore != nil {
+		conditions = append(conditions, "datetime(created_at) < datetime(?)")
+		args = append(args, filter.CreatedBefore.UTC().Format(time.RFC3339))
 	}
-	defer rows.Close()
 
-	// Scan results
-	notifications := make([]*domain.Notification, 0)
-	for rows.Next() {
-		n, err := scanNotification(rows)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan notification: %w", err)
-		}
-		notifications = append(notifications, n)
+	if filter.CampaignID != nil {
+		conditions = append(conditions, "campaign_id = ?")
+		args = append(args, *filter.CampaignID)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating rows: %w", err)
+	if filter.Settled {
+		conditions = append(conditions, "NOT (status = 'FAILED' AND retry_count < max_retries)")
 	}
 
-	return notifications, total, nil
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " AND " + strings.Join(conditions, " AND "), args
 }
 
 func (r *SQLiteNotificationRepository) UpdateStatus(
@@ -550,11 +609,9 @@ func (r *SQLiteNotificationRepository) UpdateStatus(
 	`
 
 	// Get current version first
-	var currentVersion int
-	versionQuery := `SELECT version FROM notifications WHERE id = ?`
-	err := r.db.QueryRowContext(ctx, versionQuery, id).Scan(&currentVersion)
+	currentVersion, err := r.currentVersion(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to get notification version: %w", err)
+		return err
 	}
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -573,12 +630,56 @@ func (r *SQLiteNotificationRepository) UpdateStatus(
 		return err
 	}
 	if rows == 0 {
-		return fmt.Errorf("optimistic locking conflict for notification %s", id)
+		return fmt.Errorf("%w for notification %s", domain.ErrNotificationConflict, id)
 	}
 
 	return nil
 }
 
+// ClaimPending leases due notifications one by one; a notification another
+// worker leased in the meantime is no longer due and is skipped.
+func (r *SQLiteNotificationRepository) ClaimPending(ctx context.Context, limit int, until time.Time) ([]*domain.Notification, error) {
+	due, err := r.FindPending(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*domain.Notification, 0, len(due))
+	for _, n := range due {
+		result, err := r.db.ExecContext(ctx, `
+		UPDATE notifications
+		SET next_attempt_at = ?,
+			version = version + 1
+		WHERE id = ?
+			AND status IN ('PENDING', 'FAILED')
+			AND (next_attempt_at IS NULL OR next_attempt_at <= ?)
+		`, formatAttemptTime(until), n.ID, formatAttemptTime(time.Now()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim notification: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rows == 0 {
+			continue
+		}
+
+		n.NextAttemptAt = &until
+		n.Version++
+		claimed = append(claimed, n)
+	}
+
+	return claimed, nil
+}
+
+// formatAttemptTime formats next_attempt_at in UTC, so the stored strings
+// compare in time order
+func formatAttemptTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
 func (r *SQLiteNotificationRepository) IncrementRetryCount(ctx context.Context, id string) error {
 	query := `
 	UPDATE notifications 
@@ -587,9 +688,7 @@ func (r *SQLiteNotificationRepository) IncrementRetryCount(ctx context.Context,
 	WHERE id = ? AND version = ?
 	`
 
-	var currentVersion int
-	versionQuery := `SELECT version FROM notifications WHERE id = ?`
-	err := r.db.QueryRowContext(ctx, versionQuery, id).Scan(&currentVersion)
+	currentVersion, err := r.currentVersion(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -604,12 +703,195 @@ func (r *SQLiteNotificationRepository) IncrementRetryCount(ctx context.Context,
 		return err
 	}
 	if rows == 0 {
-		return fmt.Errorf("optimistic locking conflict")
+		return fmt.Errorf("%w for notification %s", domain.ErrNotificationConflict, id)
 	}
 
 	return nil
 }
 
+// currentVersion reads the version the conditional updates are guarded by
+func (r *SQLiteNotificationRepository) currentVersion(ctx context.Context, id string) (int, error) {
+	var version int
+	err := r.db.QueryRowContext(ctx, `SELECT version FROM notifications WHERE id = ?`, id).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("%w: %s", domain.ErrNotificationNotFound, id)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get notification version: %w", err)
+	}
+	return version, nil
+}
+
+func (r *SQLiteNotificationRepository) DeleteByIDs(ctx context.Context, ids []string, filter domain.NotificationFilter, archive func([]*domain.Notification) error) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	whereClause, filterArgs := filterConditions(filter, r.keyring)
+	args = append(args, filterArgs...)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `DELETE FROM notifications WHERE id IN (`+placeholders+`)`+whereClause+` RETURNING `+notificationColumns, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete notifications: %w", err)
+	}
+
+	var deleted []*domain.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows, r.keyring)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		deleted = append(deleted, n)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("failed to delete notifications: %w", err)
+	}
+	rows.Close()
+
+	if archive != nil && len(deleted) > 0 {
+		if err := archive(deleted); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to delete notifications: %w", err)
+	}
+
+	return len(deleted), nil
+}
+
+// Compact vacuums the database so purged rows give their pages back to the
+// filesystem, then truncates the WAL they were written through.
+func (r *SQLiteNotificationRepository) Compact(ctx context.Context) error {
+	var autoVacuum int
+	if err := r.db.QueryRowContext(ctx, `PRAGMA auto_vacuum`).Scan(&autoVacuum); err != nil {
+		return fmt.Errorf("failed to read auto_vacuum mode: %w", err)
+	}
+
+	vacuum := `VACUUM`
+	if autoVacuum == 2 {
+		vacuum = `PRAGMA incremental_vacuum`
+	}
+
+	if _, err := r.db.ExecContext(ctx, vacuum); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE)`); err != nil {
+		return fmt.Errorf("failed to checkpoint WAL: %w", err)
+	}
+
+	return nil
+}
+
+// Reencrypt moves up to limit rows after afterID stored in plaintext or under an
+// older key to the current key. Rows it can't decrypt are reported and skipped.
+func (r *SQLiteNotificationRepository) Reencrypt(ctx context.Context, afterID string, limit int) (ports.ReencryptBatch, error) {
+	var result ports.ReencryptBatch
+	if r.keyring == nil {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+	SELECT id, key_id, content_sealed, recipient_email, recipient_phone, recipient_device, title, body, data, html, attachments
+	FROM notifications
+	WHERE (key_id IS NULL OR key_id != ? OR content_sealed = 0) AND id > ?
+	ORDER BY id
+	LIMIT ?
+	`, r.keyring.CurrentKeyID(), afterID, limit)
+	if err != nil {
+		return result, fmt.Errorf("failed to query notifications to re-encrypt: %w", err)
+	}
+
+	type stored struct {
+		id            string
+		keyID         sql.NullString
+		contentSealed bool
+		fields        encryption.Fields
+	}
+
+	var batch []stored
+	for rows.Next() {
+		var s stored
+		var title string
+		var email, phone, device, body, data, html, attachments sql.NullString
+		if err := rows.Scan(&s.id, &s.keyID, &s.contentSealed, &email, &phone, &device, &title, &body, &data, &html, &attachments); err != nil {
+			rows.Close()
+			return result, err
+		}
+		s.fields = encryption.Fields{
+			Email:       utils.SqlNullableString(email),
+			Phone:       utils.SqlNullableString(phone),
+			Device:      utils.SqlNullableString(device),
+			Title:       &title,
+			Body:        utils.SqlNullableString(body),
+			Data:        utils.SqlNullableString(data),
+			HTML:        utils.SqlNullableString(html),
+			Attachments: utils.SqlNullableString(attachments),
+		}
+		batch = append(batch, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	for _, s := range batch {
+		result.LastID = s.id
+
+		open := r.keyring.Open
+		if !s.contentSealed {
+			open = r.keyring.OpenLegacy
+		}
+
+		fields, err := open(s.id, s.keyID.String, s.fields)
+		if err != nil {
+			result.Skipped = append(result.Skipped, err)
+			continue
+		}
+
+		sealed, err := r.keyring.Seal(s.id, fields)
+		if err != nil {
+			return result, err
+		}
+
+		// Skip rows a concurrent run already moved
+		res, err := r.db.ExecContext(ctx, `
+		UPDATE notifications
+		SET recipient_email = ?, recipient_phone = ?, recipient_device = ?, title = ?, body = ?, data = ?, html = ?,
+			attachments = ?, key_id = ?, recipient_email_bidx = ?, recipient_phone_bidx = ?, content_sealed = 1
+		WHERE id = ? AND key_id IS ? AND content_sealed = ?
+		`, sealed.Email, sealed.Phone, sealed.Device, sealed.Title, sealed.Body, sealed.Data, sealed.HTML,
+			sealed.Attachments, sealed.KeyID, sealed.EmailIndex, sealed.PhoneIndex, s.id, s.keyID, s.contentSealed)
+		if err != nil {
+			return result, fmt.Errorf("failed to re-encrypt notification %s: %w", s.id, err)
+		}
+
+		updated, err := res.RowsAffected()
+		if err != nil {
+			return result, err
+		}
+		result.Moved += int(updated)
+	}
+
+	return result, nil
+}
+
 func (r *SQLiteNotificationRepository) Close() error {
 	return r.db.Close()
 }
@@ -0,0 +1,171 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/encryption"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/repositorytest"
+)
+
+func TestNotificationRepository(t *testing.T) {
+	repositorytest.RunNotificationRepository(t, func(t *testing.T) ports.NotificationRepository {
+		repo, err := NewSQLiteNotificationRepository(filepath.Join(t.TempDir(), "notifications.db"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repo
+	})
+}
+
+func TestEncryptedNotificationRepository(t *testing.T) {
+	keyring := testKeyring(t)
+
+	repositorytest.RunNotificationRepository(t, func(t *testing.T) ports.NotificationRepository {
+		repo, err := NewSQLiteNotificationRepository(filepath.Join(t.TempDir(), "notifications.db"), keyring)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repo
+	})
+}
+
+func TestReencryptSkipsUndecryptableRows(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "notifications.db")
+
+	plain, err := NewSQLiteNotificationRepository(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"a", "b", "c"} {
+		email := id + "@example.com"
+		n, err := domain.NewNotification(id, domain.EmailNotification, domain.Recipient{ID: id, Email: &email},
+			domain.Content{Title: "Hello"}, 3, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := plain.Save(ctx, n); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// "a" claims a key the keyfile doesn't have
+	if _, err := plain.(*SQLiteNotificationRepository).db.Exec(`UPDATE notifications SET key_id = 'lost' WHERE id = 'a'`); err != nil {
+		t.Fatal(err)
+	}
+	plain.Close()
+
+	repo, err := NewSQLiteNotificationRepository(path, testKeyring(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+
+	moved, skipped := 0, 0
+	afterID := ""
+	for i := 0; i < 10; i++ {
+		batch, err := repo.Reencrypt(ctx, afterID, 1)
+		if err != nil {
+			t.Fatalf("Reencrypt: %v", err)
+		}
+		moved += batch.Moved
+		skipped += len(batch.Skipped)
+		if batch.LastID == "" {
+			break
+		}
+		afterID = batch.LastID
+	}
+
+	if moved != 2 || skipped != 1 {
+		t.Fatalf("moved %d, skipped %d, want 2 and 1", moved, skipped)
+	}
+
+	n, err := repo.FindByID(ctx, "c")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if n.Recipient.Email == nil || *n.Recipient.Email != "c@example.com" {
+		t.Fatalf("email = %v after re-encryption", n.Recipient.Email)
+	}
+}
+
+func TestReencryptSealsTitlesOfOlderRows(t *testing.T) {
+	ctx := context.Background()
+
+	repo, err := NewSQLiteNotificationRepository(filepath.Join(t.TempDir(), "notifications.db"), testKeyring(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+
+	email := "a@example.com"
+	n, err := domain.NewNotification("a", domain.EmailNotification, domain.Recipient{ID: "a", Email: &email},
+		domain.Content{Title: "Hello"}, 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(ctx, n); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rows encrypted before titles were kept theirs in plaintext
+	db := repo.(*SQLiteNotificationRepository).db
+	if _, err := db.Exec(`UPDATE notifications SET title = 'Hello', content_sealed = 0 WHERE id = 'a'`); err != nil {
+		t.Fatal(err)
+	}
+
+	if found, err := repo.FindByID(ctx, "a"); err != nil || found.Content.Title != "Hello" {
+		t.Fatalf("FindByID = %v, %v before re-encryption", found, err)
+	}
+
+	batch, err := repo.Reencrypt(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("Reencrypt: %v", err)
+	}
+	if batch.Moved != 1 {
+		t.Fatalf("moved %d, want 1", batch.Moved)
+	}
+
+	var stored string
+	if err := db.QueryRow(`SELECT title FROM notifications WHERE id = 'a'`).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored == "Hello" {
+		t.Fatal("title is still stored in plaintext")
+	}
+
+	if found, err := repo.FindByID(ctx, "a"); err != nil || found.Content.Title != "Hello" {
+		t.Fatalf("FindByID = %v, %v after re-encryption", found, err)
+	}
+}
+
+func testKeyring(t *testing.T) *encryption.Keyring {
+	t.Helper()
+
+	key := func() string {
+		b := make([]byte, 32)
+		rand.Read(b)
+		return base64.StdEncoding.EncodeToString(b)
+	}
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	content := fmt.Sprintf(`{"current_key": "k1", "keys": {"k1": %q}, "index_key": %q}`, key(), key())
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keyring, err := encryption.LoadKeyring(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyring
+}
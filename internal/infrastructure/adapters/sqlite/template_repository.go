@@ -0,0 +1,229 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	_ "modernc.org/sqlite"
+)
+
+type SQLiteTemplateRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteTemplateRepository(dbPath string) (ports.TemplateRepository, error) {
+	dsn := fmt.Sprintf("%s?_journal=WAL&_timeout=5000&_fk=true", dbPath)
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	if err := optimizeSQLite(db); err != nil {
+		return nil, fmt.Errorf("failed to optimize db: %w", err)
+	}
+
+	db.SetMaxOpenConns(5)
+	db.SetMaxIdleConns(2)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	if err := migrate(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return &SQLiteTemplateRepository{db: db}, nil
+}
+
+const templateColumns = `name, version, body, status, created_at, updated_at, published_at`
+
+func scanTemplate(row rowScanner) (*domain.TemplateVersion, error) {
+	var t domain.TemplateVersion
+	var status, createdAt, updatedAt string
+	var publishedAt sql.NullString
+
+	if err := row.Scan(&t.Name, &t.Version, &t.Body, &status, &createdAt, &updatedAt, &publishedAt); err != nil {
+		return nil, err
+	}
+
+	t.Status = domain.TemplateStatus(status)
+
+	var err error
+	if t.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return nil, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	if t.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	if publishedAt.Valid {
+		p, err := time.Parse(time.RFC3339, publishedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse published_at: %w", err)
+		}
+		t.PublishedAt = &p
+	}
+
+	return &t, nil
+}
+
+func (r *SQLiteTemplateRepository) CreateVersion(ctx context.Context, t *domain.TemplateVersion) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var version int
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) + 1 FROM email_templates WHERE name = ?`, t.Name).Scan(&version)
+	if err != nil {
+		return fmt.Errorf("failed to allocate template version: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO email_templates (`+templateColumns+`)
+	VALUES (?, ?, ?, ?, ?, ?, NULL)
+	`, t.Name, version, t.Body, string(t.Status), t.CreatedAt.Format(time.RFC3339), t.UpdatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to save template: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	t.Version = version
+	return nil
+}
+
+func (r *SQLiteTemplateRepository) UpdateVersion(ctx context.Context, t *domain.TemplateVersion) error {
+	result, err := r.db.ExecContext(ctx, `
+	UPDATE email_templates SET body = ?, updated_at = ?
+	WHERE name = ? AND version = ? AND status = 'DRAFT'
+	`, t.Body, t.UpdatedAt.Format(time.RFC3339), t.Name, t.Version)
+	if err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+
+	return requireAffected(result, domain.ErrTemplateNotDraft)
+}
+
+func (r *SQLiteTemplateRepository) DeleteVersion(ctx context.Context, name string, version int) error {
+	result, err := r.db.ExecContext(ctx, `
+	DELETE FROM email_templates WHERE name = ? AND version = ? AND status = 'DRAFT'
+	`, name, version)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	return requireAffected(result, domain.ErrTemplateNotDraft)
+}
+
+func (r *SQLiteTemplateRepository) FindVersion(ctx context.Context, name string, version int) (*domain.TemplateVersion, error) {
+	query := `SELECT ` + templateColumns + ` FROM email_templates WHERE name = ? AND version = ?`
+
+	t, err := scanTemplate(r.db.QueryRowContext(ctx, query, name, version))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan template: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *SQLiteTemplateRepository) ListVersions(ctx context.Context, name string) ([]*domain.TemplateVersion, error) {
+	query := `SELECT ` + templateColumns + ` FROM email_templates WHERE name = ? ORDER BY version DESC`
+	return r.queryTemplates(ctx, query, name)
+}
+
+func (r *SQLiteTemplateRepository) FindPublished(ctx context.Context) ([]*domain.TemplateVersion, error) {
+	query := `SELECT ` + templateColumns + ` FROM email_templates WHERE status = 'PUBLISHED' ORDER BY name`
+	return r.queryTemplates(ctx, query)
+}
+
+func (r *SQLiteTemplateRepository) Publish(ctx context.Context, name string, version int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM email_templates WHERE name = ? AND version = ?`, name, version).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		return domain.ErrTemplateNotFound
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE email_templates SET status = 'ARCHIVED', updated_at = ?
+	WHERE name = ? AND status = 'PUBLISHED'
+	`, now, name)
+	if err != nil {
+		return fmt.Errorf("failed to archive published template: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE email_templates SET status = 'PUBLISHED', published_at = ?, updated_at = ?
+	WHERE name = ? AND version = ?
+	`, now, now, name, version)
+	if err != nil {
+		return fmt.Errorf("failed to publish template: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *SQLiteTemplateRepository) Unpublish(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, `
+	UPDATE email_templates SET status = 'ARCHIVED', updated_at = ?
+	WHERE name = ? AND status = 'PUBLISHED'
+	`, time.Now().UTC().Format(time.RFC3339), name)
+	if err != nil {
+		return fmt.Errorf("failed to unpublish template: %w", err)
+	}
+
+	return requireAffected(result, domain.ErrTemplateNotFound)
+}
+
+func (r *SQLiteTemplateRepository) queryTemplates(ctx context.Context, query string, args ...any) ([]*domain.TemplateVersion, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*domain.TemplateVersion
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+
+	return templates, rows.Err()
+}
+
+func (r *SQLiteTemplateRepository) Close() error {
+	return r.db.Close()
+}
+
+func requireAffected(result sql.Result, notAffected error) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return notAffected
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/commitshark/notification-svc/internal/infrastructure/adapters/repositorytest"
+)
+
+func TestTemplateRepository(t *testing.T) {
+	repositorytest.RunTemplateRepository(t, func(t *testing.T) ports.TemplateRepository {
+		repo, err := NewSQLiteTemplateRepository(filepath.Join(t.TempDir(), "notifications.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repo
+	})
+}
@@ -0,0 +1,76 @@
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+)
+
+// Catalog holds the translated strings of one locale. Keys are either used from
+// templates through {{ t "key" }} or looked up as "<template>.subject" and
+// "<template>.preheader".
+type Catalog map[string]string
+
+// LoadCatalogs reads i18n/<locale>.json files from fsys
+func LoadCatalogs(fsys fs.FS) (map[string]Catalog, error) {
+	files, err := fs.Glob(fsys, "i18n/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs := make(map[string]Catalog, len(files))
+	for _, file := range files {
+		raw, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog %s: %w", file, err)
+		}
+
+		var catalog Catalog
+		if err := json.Unmarshal(raw, &catalog); err != nil {
+			return nil, fmt.Errorf("invalid catalog %s: %w", file, err)
+		}
+
+		locale := domain.NormalizeLocale(strings.TrimSuffix(path.Base(file), ".json"))
+		catalogs[locale] = catalog
+	}
+
+	return catalogs, nil
+}
+
+// Missing returns the keys of reference that are not translated in c
+func (c Catalog) Missing(reference Catalog) []string {
+	var missing []string
+	for key := range reference {
+		if _, ok := c[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// executeText renders a catalog entry such as "Your ticket for {{ .EventTitle }}"
+func executeText(name, text string, data any) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -2,5 +2,5 @@ package templates
 
 import "embed"
 
-//go:embed emails/*.html
+//go:embed emails/*.html emails/*.txt sms/*.txt push/*.txt i18n/*.json
 var Files embed.FS
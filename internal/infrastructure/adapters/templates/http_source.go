@@ -0,0 +1,56 @@
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+)
+
+// HTTPTemplateSource reads the published templates from the worker's API, so
+// the sender needs no access to the template store
+type HTTPTemplateSource struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func NewHTTPTemplateSource(url, apiKey string) ports.TemplateSource {
+	return &HTTPTemplateSource{
+		url:    url,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *HTTPTemplateSource) FindPublished(ctx context.Context) ([]*domain.TemplateVersion, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch published templates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		reason, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("template source returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(reason)))
+	}
+
+	var published []*domain.TemplateVersion
+	if err := json.NewDecoder(resp.Body).Decode(&published); err != nil {
+		return nil, fmt.Errorf("failed to decode published templates: %w", err)
+	}
+
+	return published, nil
+}
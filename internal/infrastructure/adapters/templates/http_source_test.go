@@ -0,0 +1,31 @@
+package templates
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPTemplateSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`[{"name": "welcome", "version": 3, "body": "<p>hi</p>", "status": "PUBLISHED"}]`))
+	}))
+	defer server.Close()
+
+	published, err := NewHTTPTemplateSource(server.URL, "secret").FindPublished(context.Background())
+	if err != nil {
+		t.Fatalf("FindPublished: %v", err)
+	}
+	if len(published) != 1 || published[0].Name != "welcome" || published[0].Version != 3 || published[0].Body != "<p>hi</p>" {
+		t.Errorf("published = %+v", published)
+	}
+
+	if _, err := NewHTTPTemplateSource(server.URL, "wrong").FindPublished(context.Background()); err == nil {
+		t.Error("FindPublished with a wrong key succeeded")
+	}
+}
@@ -0,0 +1,178 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"unicode/utf8"
+
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+)
+
+// Push limits shared by APNs and FCM previews
+const (
+	maxPushTitle = 65
+	maxPushBody  = 240
+)
+
+var shortChannelDirs = map[domain.NotificationType]string{
+	domain.SMSNotification:  "sms",
+	domain.PushNotification: "push",
+}
+
+// shortTemplates holds sms/<name>.txt and push/<name>.txt per locale, keyed by
+// "<dir>/<file>". Each file is its own set since push files all define
+// "title", "body" and "link".
+type shortTemplates map[string]map[string]*texttemplate.Template
+
+func (r *GoTemplateRenderer) buildShort() (shortTemplates, error) {
+	short := make(shortTemplates, len(r.catalogs))
+
+	for locale := range r.catalogs {
+		sets := map[string]*texttemplate.Template{}
+
+		for _, dir := range shortChannelDirs {
+			files, err := fs.Glob(r.fsys, dir+"/*.txt")
+			if err != nil {
+				return nil, err
+			}
+
+			for _, file := range files {
+				raw, err := fs.ReadFile(r.fsys, file)
+				if err != nil {
+					return nil, err
+				}
+
+				// "…" would switch a GSM-7 SMS to UCS-2 and halve its length
+				ellipsis := "…"
+				if dir == "sms" {
+					ellipsis = "..."
+				}
+
+				set, err := texttemplate.New(path.Base(file)).
+					Funcs(texttemplate.FuncMap{
+						"t":        r.translator(locale),
+						"truncate": truncateFunc(ellipsis),
+					}).
+					Parse(string(raw))
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+				}
+				sets[file] = set
+			}
+		}
+
+		short[locale] = sets
+	}
+
+	return short, nil
+}
+
+// RenderShort renders the SMS or push variant of a template. SMS bodies are
+// trimmed to domain.MaxSMSSegments parts; push title and body to the platform limits.
+func (r *GoTemplateRenderer) RenderShort(
+	channel domain.NotificationType,
+	templateName, locale string,
+	data any,
+) (*ports.ShortMessage, error) {
+	dir, ok := shortChannelDirs[channel]
+	if !ok {
+		return nil, domain.PermanentFailure(fmt.Errorf("channel %s has no short templates", channel))
+	}
+
+	requested := domain.NormalizeLocale(locale)
+	if requested == "" {
+		requested = r.defaultLocale
+	}
+
+	_, locale = r.templateSet(requested)
+
+	tmpl := r.lookupShort(locale, dir, templateName, requested)
+	if tmpl == nil {
+		return nil, domain.PermanentFailure(fmt.Errorf("template %s has no %s variant", templateName, dir))
+	}
+
+	if channel == domain.SMSNotification {
+		body, err := executeShort(tmpl, "", data)
+		if err != nil {
+			return nil, domain.PermanentFailure(err)
+		}
+
+		fitted := domain.FitSMS(body, domain.MaxSMSSegments)
+		if fitted != body {
+			log.Printf("[Renderer] sms/%s exceeded %d segments and was shortened", templateName, domain.MaxSMSSegments)
+		}
+
+		return &ports.ShortMessage{Body: fitted}, nil
+	}
+
+	title, err := executeShort(tmpl, "title", data)
+	if err != nil {
+		return nil, domain.PermanentFailure(err)
+	}
+	body, err := executeShort(tmpl, "body", data)
+	if err != nil {
+		return nil, domain.PermanentFailure(err)
+	}
+	link := ""
+	if tmpl.Lookup("link") != nil {
+		if link, err = executeShort(tmpl, "link", data); err != nil {
+			return nil, domain.PermanentFailure(err)
+		}
+	}
+
+	return &ports.ShortMessage{
+		Title: truncateFunc("…")(maxPushTitle, title),
+		Body:  truncateFunc("…")(maxPushBody, body),
+		Link:  link,
+	}, nil
+}
+
+func (r *GoTemplateRenderer) lookupShort(locale, dir, name, requested string) *texttemplate.Template {
+	sets := r.short[locale]
+	for _, candidate := range localeChain(requested) {
+		if t, ok := sets[fmt.Sprintf("%s/%s.%s.txt", dir, name, candidate)]; ok {
+			return t
+		}
+	}
+	return sets[fmt.Sprintf("%s/%s.txt", dir, name)]
+}
+
+// executeShort runs the named block, or the whole file when name is empty,
+// and collapses whitespace so template line breaks don't reach the device.
+func executeShort(tmpl *texttemplate.Template, name string, data any) (string, error) {
+	var buf bytes.Buffer
+
+	var err error
+	if name == "" {
+		err = tmpl.Execute(&buf, data)
+	} else {
+		err = tmpl.ExecuteTemplate(&buf, name, data)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(strings.Fields(buf.String()), " "), nil
+}
+
+// truncateFunc backs {{ truncate 40 .EventTitle }} in short templates
+func truncateFunc(ellipsis string) func(max int, s string) string {
+	return func(max int, s string) string {
+		if max <= 0 || utf8.RuneCountInString(s) <= max {
+			return s
+		}
+
+		keep := max - utf8.RuneCountInString(ellipsis)
+		if keep < 1 {
+			keep = 1
+		}
+		runes := []rune(s)
+		return strings.TrimSpace(string(runes[:keep])) + ellipsis
+	}
+}
@@ -2,61 +2,138 @@ package templates
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
 	"io/fs"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
 
+	"github.com/commitshark/notification-svc/internal/domain"
 	"github.com/commitshark/notification-svc/internal/domain/ports"
 	domain_template "github.com/commitshark/notification-svc/internal/domain/templates"
 )
 
+const (
+	DefaultLocale = "en"
+
+	defaultReloadInterval = 30 * time.Second
+
+	unsubscribeURL = "https://id.eventor.com.ng/email/unsubscribe"
+)
+
 type GoTemplateRenderer struct {
-	templates *template.Template
+	fsys          fs.FS
+	defaultLocale string
+	catalogs      map[string]Catalog
+
+	mu sync.RWMutex
+	// one template set per catalog locale, each with its own "t" function
+	templates map[string]*template.Template
+	// explicit plain-text templates (emails/<name>.txt), same layout as templates
+	texts map[string]*texttemplate.Template
+	// SMS and push variants
+	short shortTemplates
+	// stored version of each overridden file stem, e.g. "welcome.fr" -> 3
+	versions    map[string]int
+	fingerprint string
 }
 
 type EmailLayoutData struct {
 	Subject        string
 	Preheader      string
 	UnsubscribeURL string
+	Locale         string
 	Body           template.HTML
 }
 
-func NewGoTemplateRenderer(fsys fs.FS) (ports.TemplateRenderer, error) {
-	tmpl, err := template.ParseFS(fsys, "emails/*.html")
+type TextLayoutData struct {
+	Subject        string
+	Preheader      string
+	UnsubscribeURL string
+	Locale         string
+	Body           string
+}
+
+func NewGoTemplateRenderer(fsys fs.FS, defaultLocale string) (*GoTemplateRenderer, error) {
+	if defaultLocale == "" {
+		defaultLocale = DefaultLocale
+	}
+	defaultLocale = domain.NormalizeLocale(defaultLocale)
+
+	catalogs, err := LoadCatalogs(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := catalogs[defaultLocale]; !ok {
+		catalogs[defaultLocale] = Catalog{}
+	}
+
+	r := &GoTemplateRenderer{
+		fsys:          fsys,
+		defaultLocale: defaultLocale,
+		catalogs:      catalogs,
+	}
+
+	templates, versions, err := r.build(nil)
 	if err != nil {
 		return nil, err
 	}
+	r.templates = templates
+	r.versions = versions
+
+	if r.texts, err = r.buildTexts(); err != nil {
+		return nil, err
+	}
+
+	if r.short, err = r.buildShort(); err != nil {
+		return nil, err
+	}
 
 	// Log all parsed templates
-	for _, t := range tmpl.Templates() {
+	for _, t := range r.templates[defaultLocale].Templates() {
 		fmt.Println("Parsed template:", t.Name())
 	}
 
-	return &GoTemplateRenderer{
-		templates: tmpl,
-	}, nil
+	r.reportMissingTranslations()
+
+	return r, nil
 }
 
+// Render renders the template for the given locale inside layout.html. Its
+// errors are permanent failures, since rendering again gives the same result.
 func (r *GoTemplateRenderer) Render(
-	templateName, subject string,
+	templateName, locale, subject string,
 	data any,
 	preHeader *string,
 ) (string, error) {
-	templateNameFull := fmt.Sprintf("%s.html", templateName)
-
 	d, ok := data.(domain_template.EmailTemplateData)
 	if !ok {
-		return "", fmt.Errorf("invalid template data type")
+		return "", domain.PermanentFailure(fmt.Errorf("invalid template data type"))
 	}
 
-	if r.templates.Lookup(templateNameFull) == nil {
-		return "", fmt.Errorf("template %s not found", templateNameFull)
+	// Template variants may exist for locales without a catalog
+	requested := domain.NormalizeLocale(locale)
+	if requested == "" {
+		requested = r.defaultLocale
+	}
+
+	set, locale := r.templateSet(requested)
+
+	tmpl := r.lookup(set, templateName, requested)
+	if tmpl == nil {
+		return "", domain.PermanentFailure(fmt.Errorf("template %s.html not found", templateName))
 	}
 
 	var buf bytes.Buffer
-	err := r.templates.ExecuteTemplate(&buf, templateNameFull, d)
+	err := tmpl.Execute(&buf, d)
 	if err != nil {
-		return "", err
+		return "", domain.PermanentFailure(err)
 	}
 
 	preHeaderStr := ""
@@ -67,15 +144,358 @@ func (r *GoTemplateRenderer) Render(
 	layoutData := EmailLayoutData{
 		Subject:        subject,
 		Preheader:      preHeaderStr,
-		UnsubscribeURL: "https://id.eventor.com.ng/email/unsubscribe",
+		UnsubscribeURL: unsubscribeURL,
+		Locale:         locale,
 		Body:           template.HTML(buf.String()),
 	}
 
+	layout := r.lookup(set, "layout", requested)
+	if layout == nil {
+		return "", domain.PermanentFailure(fmt.Errorf("template layout.html not found"))
+	}
+
 	var layoutBuf bytes.Buffer
-	err = r.templates.ExecuteTemplate(&layoutBuf, "layout.html", layoutData)
+	err = layout.Execute(&layoutBuf, layoutData)
 	if err != nil {
-		return "", err
+		return "", domain.PermanentFailure(err)
 	}
 
 	return layoutBuf.String(), nil
 }
+
+// RenderText renders the explicit text template for the locale inside layout.txt.
+// It returns an empty string when the template has no text variant.
+func (r *GoTemplateRenderer) RenderText(
+	templateName, locale, subject string,
+	data any,
+	preHeader *string,
+) (string, error) {
+	requested := domain.NormalizeLocale(locale)
+	if requested == "" {
+		requested = r.defaultLocale
+	}
+
+	// An edited HTML template would disagree with the embedded text, convert it instead
+	if r.Version(templateName, requested) > 0 {
+		return "", nil
+	}
+
+	_, locale = r.templateSet(requested)
+	set := r.texts[locale]
+
+	tmpl := lookupText(set, templateName, requested)
+	if tmpl == nil {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", domain.PermanentFailure(err)
+	}
+
+	layout := lookupText(set, "layout", requested)
+	if layout == nil {
+		return buf.String(), nil
+	}
+
+	preHeaderStr := ""
+	if preHeader != nil {
+		preHeaderStr = *preHeader
+	}
+
+	var layoutBuf bytes.Buffer
+	err := layout.Execute(&layoutBuf, TextLayoutData{
+		Subject:        subject,
+		Preheader:      preHeaderStr,
+		UnsubscribeURL: unsubscribeURL,
+		Locale:         locale,
+		Body:           strings.TrimSpace(buf.String()),
+	})
+	if err != nil {
+		return "", domain.PermanentFailure(err)
+	}
+
+	return layoutBuf.String(), nil
+}
+
+// Localize returns the preheader from the locale's catalog ("<template>.preheader"),
+// falling back to the given one. The producer's subject wins; "<template>.subject"
+// is only used when none was sent.
+func (r *GoTemplateRenderer) Localize(templateName, locale, subject string, preHeader *string, data any) (string, *string) {
+	_, locale = r.templateSet(locale)
+
+	if subject == "" {
+		if s, ok := r.catalogString(locale, templateName+".subject", data); ok {
+			subject = s
+		}
+	}
+
+	if p, ok := r.catalogString(locale, templateName+".preheader", data); ok {
+		preHeader = &p
+	}
+
+	return subject, preHeader
+}
+
+// Version returns the stored version Render uses for templateName, 0 when the
+// embedded copy is used
+func (r *GoTemplateRenderer) Version(templateName, locale string) int {
+	requested := domain.NormalizeLocale(locale)
+	if requested == "" {
+		requested = r.defaultLocale
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := r.templates[r.resolveLocale(requested)]
+	tmpl := r.lookup(set, templateName, requested)
+	if tmpl == nil {
+		return 0
+	}
+	return r.versions[strings.TrimSuffix(tmpl.Name(), ".html")]
+}
+
+// Check parses body the way a stored template would be parsed
+func (r *GoTemplateRenderer) Check(name, body string) error {
+	_, err := template.New(name + ".html").Funcs(stubFuncs).Parse(body)
+	return err
+}
+
+// Reload rebuilds the template sets when the published templates changed. A
+// template that no longer parses is skipped so the embedded copy keeps working.
+func (r *GoTemplateRenderer) Reload(ctx context.Context, source ports.TemplateSource) error {
+	published, err := source.FindPublished(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load published templates: %w", err)
+	}
+
+	fingerprint := templatesFingerprint(published)
+
+	r.mu.RLock()
+	unchanged := fingerprint == r.fingerprint
+	r.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	templates, versions, err := r.build(published)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.templates = templates
+	r.versions = versions
+	r.fingerprint = fingerprint
+	r.mu.Unlock()
+
+	log.Printf("[Renderer] loaded %d published template(s)", len(versions))
+	return nil
+}
+
+// Watch reloads the published templates every interval until ctx is done
+func (r *GoTemplateRenderer) Watch(ctx context.Context, source ports.TemplateSource, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+
+	if err := r.Reload(ctx, source); err != nil {
+		log.Printf("[Renderer] reload error: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Reload(ctx, source); err != nil {
+				log.Printf("[Renderer] reload error: %v", err)
+			}
+		}
+	}
+}
+
+// build parses the embedded templates, applies the overrides and clones one set
+// per catalog locale.
+func (r *GoTemplateRenderer) build(overrides []*domain.TemplateVersion) (map[string]*template.Template, map[string]int, error) {
+	base, err := template.New("").Funcs(stubFuncs).ParseFS(r.fsys, "emails/*.html")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	versions := make(map[string]int, len(overrides))
+	for _, o := range overrides {
+		if err := r.Check(o.Name, o.Body); err != nil {
+			log.Printf("[Renderer] skipping %s v%d: %v", o.Name, o.Version, err)
+			continue
+		}
+		if _, err := base.New(o.Name + ".html").Parse(o.Body); err != nil {
+			return nil, nil, fmt.Errorf("failed to install %s v%d: %w", o.Name, o.Version, err)
+		}
+		versions[o.Name] = o.Version
+	}
+
+	templates := make(map[string]*template.Template, len(r.catalogs))
+	for locale := range r.catalogs {
+		set, err := base.Clone()
+		if err != nil {
+			return nil, nil, err
+		}
+		set.Funcs(template.FuncMap{"t": r.translator(locale)})
+		templates[locale] = set
+	}
+
+	return templates, versions, nil
+}
+
+// buildTexts parses the plain-text templates once per catalog locale
+func (r *GoTemplateRenderer) buildTexts() (map[string]*texttemplate.Template, error) {
+	texts := make(map[string]*texttemplate.Template, len(r.catalogs))
+
+	files, err := fs.Glob(r.fsys, "emails/*.txt")
+	if err != nil || len(files) == 0 {
+		return texts, err
+	}
+
+	for locale := range r.catalogs {
+		set, err := texttemplate.New("").
+			Funcs(texttemplate.FuncMap{"t": r.translator(locale)}).
+			ParseFS(r.fsys, "emails/*.txt")
+		if err != nil {
+			return nil, err
+		}
+		texts[locale] = set
+	}
+
+	return texts, nil
+}
+
+// templateSet returns the set of the closest locale with a catalog
+func (r *GoTemplateRenderer) templateSet(locale string) (*template.Template, string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	locale = r.resolveLocale(locale)
+	return r.templates[locale], locale
+}
+
+// lookup finds the most specific variant: name.fr-ca.html, name.fr.html, name.html
+func (r *GoTemplateRenderer) lookup(set *template.Template, name, locale string) *template.Template {
+	for _, candidate := range localeChain(locale) {
+		if t := set.Lookup(fmt.Sprintf("%s.%s.html", name, candidate)); t != nil {
+			return t
+		}
+	}
+	return set.Lookup(name + ".html")
+}
+
+func lookupText(set *texttemplate.Template, name, locale string) *texttemplate.Template {
+	if set == nil {
+		return nil
+	}
+	for _, candidate := range localeChain(locale) {
+		if t := set.Lookup(fmt.Sprintf("%s.%s.txt", name, candidate)); t != nil {
+			return t
+		}
+	}
+	return set.Lookup(name + ".txt")
+}
+
+// resolveLocale maps a requested locale to the closest locale with a catalog
+func (r *GoTemplateRenderer) resolveLocale(locale string) string {
+	for _, candidate := range localeChain(domain.NormalizeLocale(locale)) {
+		if _, ok := r.templates[candidate]; ok {
+			return candidate
+		}
+	}
+	return r.defaultLocale
+}
+
+func (r *GoTemplateRenderer) catalogString(locale, key string, data any) (string, bool) {
+	value, ok := r.catalogs[locale][key]
+	if !ok && locale != r.defaultLocale {
+		value, ok = r.catalogs[r.defaultLocale][key]
+	}
+	if !ok {
+		return "", false
+	}
+
+	out, err := executeText(key, value, data)
+	if err != nil {
+		log.Printf("[Renderer] catalog entry %s (%s): %v", key, locale, err)
+		return "", false
+	}
+
+	return out, true
+}
+
+// translator is the "t" function of locale's templates. Entries name their
+// placeholders like subjects do, e.g. "Welcome, {{ .Name }}!", and are
+// rendered with the data passed after the key: {{ t "welcome.heading" . }}
+func (r *GoTemplateRenderer) translator(locale string) func(key string, data ...any) string {
+	return func(key string, data ...any) string {
+		value, ok := r.catalogs[locale][key]
+		if !ok {
+			value, ok = r.catalogs[r.defaultLocale][key]
+		}
+		if !ok {
+			return key
+		}
+
+		var dot any
+		if len(data) > 0 {
+			dot = data[0]
+		}
+
+		out, err := executeText(key, value, dot)
+		if err != nil {
+			log.Printf("[Renderer] catalog entry %s (%s): %v", key, locale, err)
+			return key
+		}
+		return out
+	}
+}
+
+// reportMissingTranslations logs catalog keys present in the default locale
+// but missing from other locales.
+func (r *GoTemplateRenderer) reportMissingTranslations() {
+	reference := r.catalogs[r.defaultLocale]
+
+	for locale, catalog := range r.catalogs {
+		if locale == r.defaultLocale {
+			continue
+		}
+
+		missing := catalog.Missing(reference)
+		if len(missing) > 0 {
+			log.Printf("[Renderer] locale %s is missing %d translation(s): %s", locale, len(missing), strings.Join(missing, ", "))
+		}
+	}
+}
+
+var stubFuncs = template.FuncMap{"t": func(key string, data ...any) string { return key }}
+
+func templatesFingerprint(published []*domain.TemplateVersion) string {
+	keys := make([]string, 0, len(published))
+	for _, t := range published {
+		keys = append(keys, fmt.Sprintf("%s@%d", t.Name, t.Version))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// localeChain returns "fr-ca", "fr" for "fr-ca"
+func localeChain(locale string) []string {
+	if locale == "" {
+		return nil
+	}
+	chain := []string{locale}
+	if i := strings.Index(locale, "-"); i > 0 {
+		chain = append(chain, locale[:i])
+	}
+	return chain
+}
@@ -0,0 +1,68 @@
+package infragrpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// apiKeyMetadata is the metadata key callers send the API key in
+	apiKeyMetadata = "x-api-key"
+	// callerMetadata names the calling service, idempotency keys are scoped to it
+	callerMetadata  = "x-caller"
+	maxCallerLength = 64
+)
+
+func authorize(ctx context.Context, expected string) error {
+	if expected == "" {
+		return status.Error(codes.Unavailable, "API key not configured on server")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(apiKeyMetadata)
+	if len(values) == 0 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(expected)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid API key")
+	}
+
+	return nil
+}
+
+// callerFromContext returns the calling service named in the metadata, empty
+// when none is
+func callerFromContext(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(callerMetadata)
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	caller := strings.TrimSpace(values[0])
+	if len(caller) > maxCallerLength || strings.Contains(caller, "/") {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be at most %d characters, without \"/\"", callerMetadata, maxCallerLength)
+	}
+	return caller, nil
+}
+
+func unaryAPIKeyInterceptor(expected string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorize(ctx, expected); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAPIKeyInterceptor(expected string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), expected); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
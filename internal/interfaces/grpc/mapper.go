@@ -0,0 +1,141 @@
+package infragrpc
+
+import (
+	"fmt"
+
+	pb "github.com/commitshark/notification-svc/gen/notificationpb"
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/events"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var channels = map[pb.Channel]domain.NotificationType{
+	pb.Channel_CHANNEL_EMAIL: domain.EmailNotification,
+	pb.Channel_CHANNEL_SMS:   domain.SMSNotification,
+	pb.Channel_CHANNEL_PUSH:  domain.PushNotification,
+}
+
+var statuses = map[domain.NotificationStatus]pb.Status{
+	domain.StatusPending:   pb.Status_STATUS_PENDING,
+	domain.StatusSent:      pb.Status_STATUS_SENT,
+	domain.StatusFailed:    pb.Status_STATUS_FAILED,
+	domain.StatusDelivered: pb.Status_STATUS_DELIVERED,
+	domain.StatusCancelled: pb.Status_STATUS_CANCELLED,
+	domain.StatusRejected:  pb.Status_STATUS_REJECTED,
+}
+
+func toProtoStatus(s domain.NotificationStatus) pb.Status {
+	return statuses[s]
+}
+
+func toProtoChannel(t domain.NotificationType) pb.Channel {
+	for c, nt := range channels {
+		if nt == t {
+			return c
+		}
+	}
+	return pb.Channel_CHANNEL_UNSPECIFIED
+}
+
+func toDomainStatus(s pb.Status) (domain.NotificationStatus, bool) {
+	for ds, ps := range statuses {
+		if ps == s {
+			return ds, true
+		}
+	}
+	return "", false
+}
+
+// toPayload converts a request to the payload the Kafka consumer receives, so
+// both go through the same ingestion path
+func toPayload(req *pb.NotificationRequest) (events.NotificationMessagePayload, error) {
+	channel, ok := channels[req.GetChannel()]
+	if !ok {
+		return events.NotificationMessagePayload{}, fmt.Errorf("unsupported channel %s", req.GetChannel())
+	}
+
+	payload := events.NotificationMessagePayload{
+		Type:     req.GetType(),
+		Channel:  string(channel),
+		UserID:   req.GetUserId(),
+		Subject:  req.GetSubject(),
+		Message:  req.Message,
+		HTML:     req.Html,
+		Template: req.Template,
+		Locale:   req.Locale,
+	}
+
+	if req.GetData() != nil {
+		data := req.GetData().AsMap()
+		payload.Data = &data
+	}
+
+	for _, a := range req.GetAttachments() {
+		payload.Attachments = append(payload.Attachments, domain.Attachment{
+			Filename:    a.GetFilename(),
+			ContentType: a.GetContentType(),
+			Disposition: domain.AttachmentDisposition(a.GetDisposition()),
+			ContentID:   a.GetContentId(),
+			Data:        a.GetData(),
+			URL:         a.GetUrl(),
+		})
+	}
+
+	return payload, nil
+}
+
+func toFilter(req *pb.ListNotificationsRequest) domain.NotificationFilter {
+	filter := domain.NotificationFilter{
+		IsMarketing: req.IsMarketing,
+		Query:       req.GetQuery(),
+		Template:    req.Template,
+		Recipient:   req.GetRecipient(),
+	}
+
+	if s, ok := toDomainStatus(req.GetStatus()); ok {
+		filter.Status = &s
+	}
+	if t, ok := channels[req.GetChannel()]; ok {
+		filter.Type = &t
+	}
+	if req.GetCreatedAfter() != nil {
+		t := req.GetCreatedAfter().AsTime()
+		filter.CreatedAfter = &t
+	}
+	if req.GetCreatedBefore() != nil {
+		t := req.GetCreatedBefore().AsTime()
+		filter.CreatedBefore = &t
+	}
+
+	return filter
+}
+
+// toProtoNotification leaves out the body and data, which may hold secrets
+func toProtoNotification(n *domain.Notification) *pb.Notification {
+	msg := &pb.Notification{
+		Id:               n.ID,
+		Channel:          toProtoChannel(n.Type),
+		Status:           toProtoStatus(n.Status),
+		UserId:           n.Recipient.ID,
+		Email:            n.Recipient.Email,
+		Phone:            n.Recipient.Phone,
+		DeviceId:         n.Recipient.DeviceID,
+		RecipientSpec:    n.Recipient.Spec,
+		Subject:          n.Content.Title,
+		Template:         n.Content.Template,
+		Locale:           n.Content.Locale,
+		IsMarketing:      n.IsMarketing == 1,
+		RetryCount:       int32(n.RetryCount),
+		MaxRetries:       int32(n.MaxRetries),
+		ProviderResponse: n.ProviderResponse,
+		TemplateVersion:  int32(n.TemplateVersion),
+		ResentFrom:       n.ResentFrom,
+		CreatedAt:        timestamppb.New(n.CreatedAt),
+	}
+
+	if n.SentAt != nil {
+		msg.SentAt = timestamppb.New(*n.SentAt)
+	}
+
+	return msg
+}
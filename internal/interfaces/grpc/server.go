@@ -0,0 +1,230 @@
+package infragrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/commitshark/notification-svc/gen/notificationpb"
+	"github.com/commitshark/notification-svc/internal/application/services"
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxBatchSize    = 100
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// watchInterval is how often WatchStatus polls for status changes
+	watchInterval = time.Second
+)
+
+// NotificationServer implements GrpcNotificationService on top of the same
+// services as the Kafka consumer and the HTTP API
+type NotificationServer struct {
+	pb.UnimplementedGrpcNotificationServiceServer
+
+	notificationRepo    ports.NotificationRepository
+	notificationService *services.NotificationService
+	ingestionService    *services.IngestionService
+}
+
+func NewNotificationServer(
+	notificationRepo ports.NotificationRepository,
+	notificationService *services.NotificationService,
+	ingestionService *services.IngestionService,
+) *NotificationServer {
+	return &NotificationServer{
+		notificationRepo:    notificationRepo,
+		notificationService: notificationService,
+		ingestionService:    ingestionService,
+	}
+}
+
+// NewServer returns a gRPC server with the notification service registered,
+// authenticating callers with apiKey
+func NewServer(s *NotificationServer, apiKey string) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryAPIKeyInterceptor(apiKey)),
+		grpc.StreamInterceptor(streamAPIKeyInterceptor(apiKey)),
+	)
+	pb.RegisterGrpcNotificationServiceServer(server, s)
+	return server
+}
+
+func (s *NotificationServer) SendNotification(ctx context.Context, req *pb.SendNotificationRequest) (*pb.SendNotificationResponse, error) {
+	if req.GetNotification() == nil {
+		return nil, status.Error(codes.InvalidArgument, "notification is required")
+	}
+
+	payload, err := toPayload(req.GetNotification())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	caller, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.ingestionService.Ingest(ctx, services.IdempotentID(caller, req.GetIdempotencyKey(), 0), payload)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.SendNotificationResponse{
+		Id:        result.ID,
+		Status:    toProtoStatus(result.Status),
+		Duplicate: result.Duplicate,
+		Reason:    result.Reason,
+	}, nil
+}
+
+// SendBatch validates the whole batch up front; after that each notification
+// succeeds or fails on its own
+func (s *NotificationServer) SendBatch(ctx context.Context, req *pb.SendBatchRequest) (*pb.SendBatchResponse, error) {
+	if len(req.GetNotifications()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one notification is required")
+	}
+
+	if len(req.GetNotifications()) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d notifications are allowed per batch", maxBatchSize)
+	}
+
+	caller, err := callerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, n := range req.GetNotifications() {
+		payload, err := toPayload(n)
+		if err == nil {
+			err = s.ingestionService.Validate(payload)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "notifications[%d]: %v", i, err)
+		}
+	}
+
+	resp := &pb.SendBatchResponse{
+		Results: make([]*pb.BatchResult, len(req.GetNotifications())),
+	}
+
+	for i, n := range req.GetNotifications() {
+		item := &pb.BatchResult{Index: int32(i)}
+
+		payload, _ := toPayload(n)
+		result, err := s.ingestionService.Ingest(ctx, services.IdempotentID(caller, req.GetIdempotencyKey(), i), payload)
+		if err != nil {
+			item.Error = err.Error()
+		} else {
+			item.Id = result.ID
+			item.Status = toProtoStatus(result.Status)
+			item.Duplicate = result.Duplicate
+			item.Reason = result.Reason
+		}
+
+		resp.Results[i] = item
+	}
+
+	return resp, nil
+}
+
+func (s *NotificationServer) GetNotification(ctx context.Context, req *pb.GetNotificationRequest) (*pb.Notification, error) {
+	n, err := s.notificationService.GetNotification(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toProtoNotification(n), nil
+}
+
+func (s *NotificationServer) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	page := int(req.GetPage())
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize < 1 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+
+	notifications, total, err := s.notificationRepo.PaginatedList(ctx, page, pageSize, toFilter(req))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &pb.ListNotificationsResponse{
+		Notifications: make([]*pb.Notification, len(notifications)),
+		Total:         int32(total),
+		Page:          int32(page),
+		PageSize:      int32(pageSize),
+	}
+	for i, n := range notifications {
+		resp.Notifications[i] = toProtoNotification(n)
+	}
+
+	return resp, nil
+}
+
+// WatchStatus sends the current status, then every change, and ends once the
+// notification can no longer be sent (sent, cancelled or out of retries)
+func (s *NotificationServer) WatchStatus(req *pb.WatchStatusRequest, stream grpc.ServerStreamingServer[pb.StatusUpdate]) error {
+	ctx := stream.Context()
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	var last *pb.StatusUpdate
+	for {
+		n, err := s.notificationService.GetNotification(ctx, req.GetId())
+		if err != nil {
+			return toStatusError(err)
+		}
+
+		update := &pb.StatusUpdate{
+			Id:               n.ID,
+			Status:           toProtoStatus(n.Status),
+			RetryCount:       int32(n.RetryCount),
+			ProviderResponse: n.ProviderResponse,
+			Final:            !n.CanBeSent(),
+		}
+
+		if last == nil || last.Status != update.Status || last.RetryCount != update.RetryCount || update.Final {
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+			last = update
+		}
+
+		if update.Final {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidPayload):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrNotificationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrIdempotencyKeyReused):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, fmt.Sprintf("notification service: %v", err))
+	}
+}
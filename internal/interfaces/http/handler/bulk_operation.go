@@ -0,0 +1,100 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	applicationdto "github.com/commitshark/notification-svc/internal/application/dto"
+	"github.com/commitshark/notification-svc/internal/application/services"
+	"github.com/go-chi/chi"
+)
+
+type BulkOperationHandler struct {
+	bulkService *services.BulkOperationService
+}
+
+func NewBulkOperationHandler(bulkService *services.BulkOperationService) *BulkOperationHandler {
+	return &BulkOperationHandler{
+		bulkService: bulkService,
+	}
+}
+
+// StartBulkOperation counts the matches on a dry run, otherwise starts a job
+func (h *BulkOperationHandler) StartBulkOperation(w http.ResponseWriter, r *http.Request) {
+	var req applicationdto.BulkOperationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// Guard against requeueing the whole table by accident
+	if req.Filter.IsEmpty() {
+		writeError(w, http.StatusBadRequest, "A filter is required", nil)
+		return
+	}
+
+	action := services.BulkAction(req.Action)
+	if action != services.BulkRequeue && action != services.BulkResend {
+		writeError(w, http.StatusBadRequest, "Action must be requeue or resend", nil)
+		return
+	}
+
+	filter := req.Filter.ToFilter()
+
+	if req.DryRun {
+		matched, err := h.bulkService.Count(r.Context(), action, filter)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to count notifications", err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, applicationdto.BulkDryRunResponse{Action: req.Action, Matched: matched})
+		return
+	}
+
+	job, err := h.bulkService.Start(r.Context(), action, filter, req.RatePerSecond)
+	if err != nil {
+		writeBulkError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (h *BulkOperationHandler) ListBulkOperations(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.bulkService.List())
+}
+
+func (h *BulkOperationHandler) GetBulkOperation(w http.ResponseWriter, r *http.Request) {
+	job, err := h.bulkService.Get(chi.URLParam(r, "id"))
+	if err != nil {
+		writeBulkError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (h *BulkOperationHandler) CancelBulkOperation(w http.ResponseWriter, r *http.Request) {
+	job, err := h.bulkService.Cancel(chi.URLParam(r, "id"))
+	if err != nil {
+		writeBulkError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func writeBulkError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrBulkJobNotFound):
+		writeError(w, http.StatusNotFound, err.Error(), nil)
+	case errors.Is(err, services.ErrBulkJobFinished):
+		writeError(w, http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, services.ErrUnknownBulkAction):
+		writeError(w, http.StatusBadRequest, err.Error(), nil)
+	default:
+		writeError(w, http.StatusInternalServerError, "Bulk operation failed", err)
+	}
+}
@@ -0,0 +1,138 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	applicationdto "github.com/commitshark/notification-svc/internal/application/dto"
+	"github.com/commitshark/notification-svc/internal/application/services"
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+type CampaignHandler struct {
+	campaignService *services.CampaignService
+}
+
+func NewCampaignHandler(campaignService *services.CampaignService) *CampaignHandler {
+	return &CampaignHandler{
+		campaignService: campaignService,
+	}
+}
+
+func (h *CampaignHandler) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	var req applicationdto.CreateCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	c, err := domain.NewCampaign(
+		uuid.NewString(),
+		req.Name,
+		domain.NotificationType(req.Channel),
+		req.Template,
+		req.Subject,
+		req.Locale,
+		req.Data,
+		req.UserIDs,
+		req.RatePerSecond,
+	)
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+
+	if err := h.campaignService.Create(r.Context(), c); err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, applicationdto.ToCampaignDto(c))
+}
+
+func (h *CampaignHandler) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	campaigns, err := h.campaignService.List(r.Context())
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.ToCampaignDtos(campaigns))
+}
+
+func (h *CampaignHandler) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	c, err := h.campaignService.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.ToCampaignDto(c))
+}
+
+func (h *CampaignHandler) GetCampaignStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.campaignService.Stats(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (h *CampaignHandler) LaunchCampaign(w http.ResponseWriter, r *http.Request) {
+	var req applicationdto.LaunchCampaignRequest
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	h.respond(w, r, func(id string) (*domain.Campaign, error) {
+		return h.campaignService.Launch(r.Context(), id, req.ScheduledAt)
+	})
+}
+
+func (h *CampaignHandler) PauseCampaign(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, func(id string) (*domain.Campaign, error) {
+		return h.campaignService.Pause(r.Context(), id)
+	})
+}
+
+func (h *CampaignHandler) ResumeCampaign(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, func(id string) (*domain.Campaign, error) {
+		return h.campaignService.Resume(r.Context(), id)
+	})
+}
+
+func (h *CampaignHandler) CancelCampaign(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, func(id string) (*domain.Campaign, error) {
+		return h.campaignService.Cancel(r.Context(), id)
+	})
+}
+
+// respond runs a status change on the campaign in the URL and writes the result
+func (h *CampaignHandler) respond(w http.ResponseWriter, r *http.Request, change func(id string) (*domain.Campaign, error)) {
+	c, err := change(chi.URLParam(r, "id"))
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.ToCampaignDto(c))
+}
+
+func writeCampaignError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrCampaignNotFound):
+		writeError(w, http.StatusNotFound, err.Error(), nil)
+	case errors.Is(err, domain.ErrCampaignInvalid):
+		writeError(w, http.StatusUnprocessableEntity, err.Error(), nil)
+	case errors.Is(err, domain.ErrCampaignTransition), errors.Is(err, domain.ErrCampaignConflict):
+		writeError(w, http.StatusConflict, err.Error(), nil)
+	default:
+		writeError(w, http.StatusInternalServerError, "Campaign operation failed", err)
+	}
+}
@@ -0,0 +1,146 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	applicationdto "github.com/commitshark/notification-svc/internal/application/dto"
+	"github.com/commitshark/notification-svc/internal/application/services"
+	"github.com/commitshark/notification-svc/internal/domain/events"
+)
+
+const (
+	maxIdempotencyKeyLength = 255
+	maxCallerLength         = 64
+)
+
+type IngestionHandler struct {
+	ingestionService *services.IngestionService
+}
+
+func NewIngestionHandler(ingestionService *services.IngestionService) *IngestionHandler {
+	return &IngestionHandler{
+		ingestionService: ingestionService,
+	}
+}
+
+// CreateNotification accepts a single NotificationMessagePayload. Repeating a
+// request with the same Idempotency-Key returns the original notification;
+// repeating the key with another payload is refused. Keys are scoped to the
+// service named in X-Caller.
+func (h *IngestionHandler) CreateNotification(w http.ResponseWriter, r *http.Request) {
+	caller, key, err := idempotencyKey(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	var payload events.NotificationMessagePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	result, err := h.ingestionService.Ingest(r.Context(), services.IdempotentID(caller, key, 0), payload)
+	if err != nil {
+		writeIngestionError(w, err)
+		return
+	}
+
+	// Rejected notifications are still recorded, so the ID is returned either way
+	status := http.StatusAccepted
+	switch {
+	case result.Duplicate:
+		status = http.StatusOK
+	case result.Reason != "":
+		status = http.StatusUnprocessableEntity
+	}
+
+	writeJSON(w, status, applicationdto.CreateNotificationResponse{
+		ID:        result.ID,
+		Status:    string(result.Status),
+		Duplicate: result.Duplicate,
+		Reason:    result.Reason,
+	})
+}
+
+// CreateNotifications accepts up to MaxBatchSize payloads. The whole batch is
+// validated up front; after that each item succeeds or fails on its own.
+func (h *IngestionHandler) CreateNotifications(w http.ResponseWriter, r *http.Request) {
+	caller, key, err := idempotencyKey(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	var req applicationdto.CreateNotificationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if len(req.Notifications) == 0 {
+		writeError(w, http.StatusBadRequest, "At least one notification is required", nil)
+		return
+	}
+
+	if len(req.Notifications) > applicationdto.MaxBatchSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("At most %d notifications are allowed per batch", applicationdto.MaxBatchSize), nil)
+		return
+	}
+
+	for i, payload := range req.Notifications {
+		if err := h.ingestionService.Validate(payload); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("notifications[%d]: %v", i, err), nil)
+			return
+		}
+	}
+
+	resp := applicationdto.CreateNotificationsResponse{
+		Results: make([]applicationdto.BatchItemResult, len(req.Notifications)),
+	}
+
+	for i, payload := range req.Notifications {
+		item := applicationdto.BatchItemResult{Index: i}
+
+		result, err := h.ingestionService.Ingest(r.Context(), services.IdempotentID(caller, key, i), payload)
+		if err != nil {
+			item.Error = err.Error()
+		} else {
+			item.ID = result.ID
+			item.Status = string(result.Status)
+			item.Duplicate = result.Duplicate
+			item.Reason = result.Reason
+		}
+
+		resp.Results[i] = item
+	}
+
+	writeJSON(w, http.StatusAccepted, resp)
+}
+
+// idempotencyKey returns the caller and the idempotency key of r
+func idempotencyKey(r *http.Request) (string, string, error) {
+	caller := strings.TrimSpace(r.Header.Get("X-Caller"))
+	if len(caller) > maxCallerLength || strings.Contains(caller, "/") {
+		return "", "", fmt.Errorf("X-Caller must be at most %d characters, without \"/\"", maxCallerLength)
+	}
+
+	key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+	if len(key) > maxIdempotencyKeyLength {
+		return "", "", fmt.Errorf("Idempotency-Key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+	return caller, key, nil
+}
+
+func writeIngestionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidPayload), errors.Is(err, services.ErrIdempotencyKeyReused):
+		writeError(w, http.StatusUnprocessableEntity, err.Error(), nil)
+	default:
+		writeError(w, http.StatusInternalServerError, "Failed to create notification", err)
+	}
+}
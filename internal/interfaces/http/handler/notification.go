@@ -1,21 +1,28 @@
 package httphandler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	applicationdto "github.com/commitshark/notification-svc/internal/application/dto"
+	"github.com/commitshark/notification-svc/internal/application/services"
 	"github.com/commitshark/notification-svc/internal/domain"
 	"github.com/commitshark/notification-svc/internal/domain/ports"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
 )
 
 type NotificationHandler struct {
-	notificationRepo ports.NotificationRepository
+	notificationRepo    ports.NotificationRepository
+	notificationService *services.NotificationService
 }
 
-func NewNotificationHandler(notificationRepo ports.NotificationRepository) *NotificationHandler {
+func NewNotificationHandler(notificationRepo ports.NotificationRepository, notificationService *services.NotificationService) *NotificationHandler {
 	return &NotificationHandler{
-		notificationRepo: notificationRepo,
+		notificationRepo:    notificationRepo,
+		notificationService: notificationService,
 	}
 }
 
@@ -47,6 +54,14 @@ func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.R
 		Type:        notifType,
 		IsMarketing: req.IsMarketing,
 		Query:       req.Query,
+		Recipient:   req.Recipient,
+
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+	}
+
+	if req.Template != "" {
+		filter.Template = &req.Template
 	}
 
 	// Get paginated notifications from repository
@@ -63,6 +78,48 @@ func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.R
 	writeJSON(w, http.StatusOK, response)
 }
 
+func (h *NotificationHandler) GetNotification(w http.ResponseWriter, r *http.Request) {
+	n, err := h.notificationService.GetNotification(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.ToNotificationDetailDto(n))
+}
+
+// ResendNotification sends a new copy linked to the original
+func (h *NotificationHandler) ResendNotification(w http.ResponseWriter, r *http.Request) {
+	n, err := h.notificationService.ResendNotification(r.Context(), chi.URLParam(r, "id"), uuid.NewString())
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, applicationdto.ToNotificationDetailDto(n))
+}
+
+func (h *NotificationHandler) CancelNotification(w http.ResponseWriter, r *http.Request) {
+	n, err := h.notificationService.CancelNotification(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.ToNotificationDetailDto(n))
+}
+
+func writeNotificationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotificationNotFound):
+		writeError(w, http.StatusNotFound, "Notification not found", err)
+	case errors.Is(err, domain.ErrNotCancellable):
+		writeError(w, http.StatusConflict, err.Error(), err)
+	default:
+		writeError(w, http.StatusInternalServerError, "Notification operation failed", err)
+	}
+}
+
 func parseListNotificationsRequest(r *http.Request) (*applicationdto.ListNotificationsRequest, error) {
 	req := &applicationdto.ListNotificationsRequest{
 		Page:     1,
@@ -112,5 +169,30 @@ func parseListNotificationsRequest(r *http.Request) (*applicationdto.ListNotific
 
 	req.IsMarketing = isMarketing
 
+	req.Template = r.URL.Query().Get("template")
+	req.Recipient = r.URL.Query().Get("recipient")
+
+	var err error
+	if req.CreatedAfter, err = parseTimeParam(r, "created_after"); err != nil {
+		return nil, err
+	}
+	if req.CreatedBefore, err = parseTimeParam(r, "created_before"); err != nil {
+		return nil, err
+	}
+
 	return req, nil
 }
+
+// parseTimeParam reads an optional RFC 3339 query parameter
+func parseTimeParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
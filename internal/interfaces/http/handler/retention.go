@@ -0,0 +1,42 @@
+package httphandler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/commitshark/notification-svc/internal/application/services"
+)
+
+type RetentionHandler struct {
+	retentionService *services.RetentionService
+}
+
+func NewRetentionHandler(retentionService *services.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+	}
+}
+
+// GetRetention returns the configured policies and the last run
+func (h *RetentionHandler) GetRetention(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.retentionService.Status())
+}
+
+func (h *RetentionHandler) StartRetentionRun(w http.ResponseWriter, r *http.Request) {
+	run, err := h.retentionService.Start(services.RetentionTriggerManual)
+	if err != nil {
+		writeRetentionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, run)
+}
+
+func writeRetentionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrRetentionRunning):
+		writeError(w, http.StatusConflict, err.Error(), nil)
+	default:
+		writeError(w, http.StatusInternalServerError, "Retention run failed", err)
+	}
+}
@@ -0,0 +1,186 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	applicationdto "github.com/commitshark/notification-svc/internal/application/dto"
+	"github.com/commitshark/notification-svc/internal/application/services"
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/commitshark/notification-svc/internal/domain/ports"
+	domain_template "github.com/commitshark/notification-svc/internal/domain/templates"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+// previewRecipient only fills the To header of previewed messages
+const previewRecipient = "preview@eventor.com.ng"
+
+type TemplateHandler struct {
+	composer            ports.EmailComposer
+	notificationService *services.NotificationService
+	templateService     *services.TemplateService
+}
+
+func NewTemplateHandler(
+	composer ports.EmailComposer,
+	notificationService *services.NotificationService,
+	templateService *services.TemplateService,
+) *TemplateHandler {
+	return &TemplateHandler{
+		composer:            composer,
+		notificationService: notificationService,
+		templateService:     templateService,
+	}
+}
+
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	published, err := h.templateService.PublishedVersions(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to fetch templates", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.ToTemplateDtos(domain_template.Definitions(), published))
+}
+
+func (h *TemplateHandler) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	var req applicationdto.PreviewTemplateRequest
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	n, err := buildTemplateNotification(uuid.NewString(), name, previewRecipient, req.Subject, req.Locale, req.Data)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	email, err := h.composer.Compose(n)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to render template", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.PreviewTemplateResponse{
+		Template: name,
+		Subject:  email.Subject,
+		HTML:     email.HTML,
+		Text:     email.Text,
+		MIME:     string(email.MIME),
+	})
+}
+
+// SendTestTemplate sends the template to the given address without looking up a user
+func (h *TemplateHandler) SendTestTemplate(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	var req applicationdto.SendTestTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	to := strings.TrimSpace(req.To)
+	if to == "" {
+		writeError(w, http.StatusBadRequest, "Recipient address is required", nil)
+		return
+	}
+
+	n, err := buildTemplateNotification(uuid.NewString(), name, to, req.Subject, req.Locale, req.Data)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	// Fail fast on rendering problems instead of queueing a message that can't be sent
+	if _, err := h.composer.Compose(n); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "Failed to render template", err)
+		return
+	}
+
+	err = h.notificationService.ProcessNotification(r.Context(), n.ID, n.Type, n.Recipient, n.Content, 0, 0)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to send test email", err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, applicationdto.SendTestTemplateResponse{
+		ID:     n.ID,
+		To:     to,
+		Status: string(domain.StatusPending),
+	})
+}
+
+func buildTemplateNotification(id, name, to, subject, locale string, data *map[string]interface{}) (*domain.Notification, error) {
+	if data == nil {
+		sample, ok := domain_template.SampleData(name)
+		if !ok {
+			return nil, domain_template.ValidateTemplateData(name, nil)
+		}
+		data = &sample
+	}
+
+	if err := domain_template.ValidateTemplateData(name, *data); err != nil {
+		return nil, err
+	}
+
+	if subject == "" {
+		subject = fmt.Sprintf("[Test] %s", name)
+	}
+
+	recipient, err := domain.NewRecipient("template-test", &to, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := recipient.Normalize(domain.EmailNotification, ""); err != nil {
+		return nil, err
+	}
+
+	content, err := domain.NewContent(subject, nil, data, nil, &name, nil, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewNotification(id, domain.EmailNotification, *recipient, *content, 0, 0)
+}
+
+func writeTemplateError(w http.ResponseWriter, err error) {
+	var validationErr *domain_template.ValidationError
+	if !errors.As(err, &validationErr) {
+		writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	status := http.StatusUnprocessableEntity
+	for _, f := range validationErr.Fields {
+		if f.Field == "template" {
+			status = http.StatusNotFound
+		}
+	}
+
+	writeJSON(w, status, map[string]interface{}{
+		"error":  validationErr.Error(),
+		"code":   status,
+		"fields": validationErr.Fields,
+	})
+}
+
+// decodeOptionalJSON decodes the body into v, allowing an empty body
+func decodeOptionalJSON(r *http.Request, v interface{}) error {
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil
+	}
+	err := json.NewDecoder(r.Body).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,155 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	applicationdto "github.com/commitshark/notification-svc/internal/application/dto"
+	"github.com/commitshark/notification-svc/internal/domain"
+	"github.com/go-chi/chi"
+)
+
+func (h *TemplateHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	versions, err := h.templateService.ListVersions(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		writeTemplateVersionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.ToTemplateVersionDtos(versions))
+}
+
+// ListPublished serves the live template versions with their bodies to the sender
+func (h *TemplateHandler) ListPublished(w http.ResponseWriter, r *http.Request) {
+	published, err := h.templateService.Published(r.Context())
+	if err != nil {
+		writeTemplateVersionError(w, err)
+		return
+	}
+
+	dtos := make([]*applicationdto.TemplateVersionDto, 0, len(published))
+	for _, t := range published {
+		dtos = append(dtos, applicationdto.ToTemplateVersionDto(t, true))
+	}
+	writeJSON(w, http.StatusOK, dtos)
+}
+
+func (h *TemplateHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid template version", err)
+		return
+	}
+
+	t, err := h.templateService.GetVersion(r.Context(), chi.URLParam(r, "name"), version)
+	if err != nil {
+		writeTemplateVersionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.ToTemplateVersionDto(t, true))
+}
+
+func (h *TemplateHandler) CreateVersion(w http.ResponseWriter, r *http.Request) {
+	var req applicationdto.SaveTemplateVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	t, err := h.templateService.CreateDraft(r.Context(), chi.URLParam(r, "name"), req.Body)
+	if err != nil {
+		writeTemplateVersionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, applicationdto.ToTemplateVersionDto(t, true))
+}
+
+func (h *TemplateHandler) UpdateVersion(w http.ResponseWriter, r *http.Request) {
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid template version", err)
+		return
+	}
+
+	var req applicationdto.SaveTemplateVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	t, err := h.templateService.UpdateDraft(r.Context(), chi.URLParam(r, "name"), version, req.Body)
+	if err != nil {
+		writeTemplateVersionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.ToTemplateVersionDto(t, true))
+}
+
+func (h *TemplateHandler) DeleteVersion(w http.ResponseWriter, r *http.Request) {
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid template version", err)
+		return
+	}
+
+	if err := h.templateService.DeleteDraft(r.Context(), chi.URLParam(r, "name"), version); err != nil {
+		writeTemplateVersionError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PublishVersion makes a version live; publishing an older version rolls back
+func (h *TemplateHandler) PublishVersion(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid template version", err)
+		return
+	}
+
+	if err := h.templateService.Publish(r.Context(), name, version); err != nil {
+		writeTemplateVersionError(w, err)
+		return
+	}
+
+	t, err := h.templateService.GetVersion(r.Context(), name, version)
+	if err != nil {
+		writeTemplateVersionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, applicationdto.ToTemplateVersionDto(t, false))
+}
+
+// Unpublish reverts a template to the copy embedded in the image
+func (h *TemplateHandler) Unpublish(w http.ResponseWriter, r *http.Request) {
+	if err := h.templateService.Unpublish(r.Context(), chi.URLParam(r, "name")); err != nil {
+		writeTemplateVersionError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeTemplateVersionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTemplateNotFound):
+		writeError(w, http.StatusNotFound, err.Error(), nil)
+	case errors.Is(err, domain.ErrTemplateNotDraft):
+		writeError(w, http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, domain.ErrInvalidTemplateKey),
+		errors.Is(err, domain.ErrTemplateBodyEmpty),
+		errors.Is(err, domain.ErrTemplateInvalid):
+		writeError(w, http.StatusUnprocessableEntity, err.Error(), nil)
+	default:
+		writeError(w, http.StatusInternalServerError, "Template operation failed", err)
+	}
+}
@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -55,6 +56,26 @@ func (authn *AuthnMiddleware) RequireAdmin(next http.Handler) http.Handler {
 	})
 }
 
+// RequireAPIKey authenticates service callers by the X-API-Key header
+func (authn *AuthnMiddleware) RequireAPIKey(expected string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if expected == "" {
+				http.Error(w, "API key not configured on server", http.StatusServiceUnavailable)
+				return
+			}
+
+			received := r.Header.Get("X-API-Key")
+			if received == "" || subtle.ConstantTimeCompare([]byte(received), []byte(expected)) != 1 {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func GetUserIDFromContext(ctx context.Context) *uuid.UUID {
 	if usr, ok := ctx.Value(userKey).(uuid.UUID); ok {
 		return &usr
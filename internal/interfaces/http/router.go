@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/commitshark/notification-svc/internal/application/services"
 	"github.com/commitshark/notification-svc/internal/domain/ports"
 	httphandler "github.com/commitshark/notification-svc/internal/interfaces/http/handler"
 	"github.com/commitshark/notification-svc/internal/interfaces/http/middlewares"
@@ -13,6 +14,15 @@ import (
 
 func NewRouter(
 	notificationRepo ports.NotificationRepository,
+	notificationService *services.NotificationService,
+	emailComposer ports.EmailComposer,
+	templateService *services.TemplateService,
+	bulkService *services.BulkOperationService,
+	campaignService *services.CampaignService,
+	ingestionService *services.IngestionService,
+	retentionService *services.RetentionService,
+	ingestionAPIKey string,
+	senderAPIKey string,
 ) http.Handler {
 	r := chi.NewRouter()
 
@@ -28,7 +38,12 @@ func NewRouter(
 	// -------------------
 	// Handlers
 	// -------------------
-	handler := httphandler.NewNotificationHandler(notificationRepo)
+	handler := httphandler.NewNotificationHandler(notificationRepo, notificationService)
+	ingestionHandler := httphandler.NewIngestionHandler(ingestionService)
+	campaignHandler := httphandler.NewCampaignHandler(campaignService)
+	bulkHandler := httphandler.NewBulkOperationHandler(bulkService)
+	retentionHandler := httphandler.NewRetentionHandler(retentionService)
+	templateHandler := httphandler.NewTemplateHandler(emailComposer, notificationService, templateService)
 
 	// -------------------
 	// Middleware
@@ -40,11 +55,57 @@ func NewRouter(
 	// -------------------
 
 	r.Route("/v1", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(authn.RequireAPIKey(ingestionAPIKey))
+
+			r.Post("/notifications", ingestionHandler.CreateNotification)
+			r.Post("/notifications/batch", ingestionHandler.CreateNotifications)
+		})
+
+		// The sender polls the published templates it renders emails with
+		r.Group(func(r chi.Router) {
+			r.Use(authn.RequireAPIKey(senderAPIKey))
+
+			r.Get("/templates/published", templateHandler.ListPublished)
+		})
+
 		r.Group(func(r chi.Router) {
 			r.Use(authn.RequireSession)
 			r.Use(authn.RequireAdmin)
 
 			r.Get("/", handler.ListNotifications)
+			r.Get("/{id}", handler.GetNotification)
+			r.Post("/{id}/resend", handler.ResendNotification)
+			r.Post("/{id}/cancel", handler.CancelNotification)
+
+			r.Post("/bulk-operations", bulkHandler.StartBulkOperation)
+			r.Get("/bulk-operations", bulkHandler.ListBulkOperations)
+			r.Get("/bulk-operations/{id}", bulkHandler.GetBulkOperation)
+			r.Post("/bulk-operations/{id}/cancel", bulkHandler.CancelBulkOperation)
+
+			r.Post("/campaigns", campaignHandler.CreateCampaign)
+			r.Get("/campaigns", campaignHandler.ListCampaigns)
+			r.Get("/campaigns/{id}", campaignHandler.GetCampaign)
+			r.Get("/campaigns/{id}/stats", campaignHandler.GetCampaignStats)
+			r.Post("/campaigns/{id}/launch", campaignHandler.LaunchCampaign)
+			r.Post("/campaigns/{id}/pause", campaignHandler.PauseCampaign)
+			r.Post("/campaigns/{id}/resume", campaignHandler.ResumeCampaign)
+			r.Post("/campaigns/{id}/cancel", campaignHandler.CancelCampaign)
+
+			r.Get("/retention", retentionHandler.GetRetention)
+			r.Post("/retention/runs", retentionHandler.StartRetentionRun)
+
+			r.Get("/templates", templateHandler.ListTemplates)
+			r.Post("/templates/{name}/preview", templateHandler.PreviewTemplate)
+			r.Post("/templates/{name}/test", templateHandler.SendTestTemplate)
+
+			r.Get("/templates/{name}/versions", templateHandler.ListVersions)
+			r.Post("/templates/{name}/versions", templateHandler.CreateVersion)
+			r.Get("/templates/{name}/versions/{version}", templateHandler.GetVersion)
+			r.Put("/templates/{name}/versions/{version}", templateHandler.UpdateVersion)
+			r.Delete("/templates/{name}/versions/{version}", templateHandler.DeleteVersion)
+			r.Post("/templates/{name}/versions/{version}/publish", templateHandler.PublishVersion)
+			r.Delete("/templates/{name}/published", templateHandler.Unpublish)
 		})
 	})
 